@@ -1,5 +1,7 @@
 package missing
 
+import "sort"
+
 // Treat slices as objects with methods.
 
 // An AnyList is a slice that can contain anything, but lacks the Contains function (as the type doesn't
@@ -23,6 +25,85 @@ func (l *AnyList[T]) Prepend(vals ...T) {
 	*l = append(vals, *l...)
 }
 
+// Inserts the supplied values into the list at the specified index. Panics if the index is out of range (see
+// InsertAt for a version that returns an error instead).
+func (l *AnyList[T]) Insert(index int, vals ...T) {
+	*l = insert(*l, index, vals...)
+}
+
+// Inserts the supplied values into the list at the specified index, the same as Insert, but returns an
+// ErrOutOfRange error rather than panicking if the index isn't between 0 and Len() (inclusive).
+func (l *AnyList[T]) InsertAt(index int, vals ...T) error {
+	if index < 0 || index > len(*l) {
+		return outOfRange(index, len(*l))
+	}
+	*l = insert(*l, index, vals...)
+	return nil
+}
+
+// Returns the value at the specified index, or an ErrOutOfRange error if there isn't one.
+func (l AnyList[T]) At(index int) (T, error) {
+	if index < 0 || index >= len(l) {
+		var t T
+		return t, outOfRange(index, len(l))
+	}
+	return l[index], nil
+}
+
+// Removes the value at the specified index from the list and returns it, or returns an ErrOutOfRange error if there
+// isn't one.
+func (l *AnyList[T]) RemoveAt(index int) (T, error) {
+	if index < 0 || index >= len(*l) {
+		var t T
+		return t, outOfRange(index, len(*l))
+	}
+	v := (*l)[index]
+	*l = remove(*l, index)
+	return v, nil
+}
+
+// Removes every value from the list for which the function returns true, and returns how many were removed.
+func (l *AnyList[T]) RemoveFunc(fn func(T) bool) int {
+	var removed int
+	*l, removed = removeFunc(*l, fn)
+	return removed
+}
+
+// Removes the last value from the list and returns it, or returns false if the list is empty.
+func (l *AnyList[T]) Pop() (T, bool) {
+	return pop((*[]T)(l))
+}
+
+// Removes the first value from the list and returns it, or returns false if the list is empty.
+func (l *AnyList[T]) Shift() (T, bool) {
+	return shift((*[]T)(l))
+}
+
+// Returns the index of the first value for which the function returns true, or -1 if there isn't one. (An AnyList
+// can't have IndexOf, as it's values might not be comparable.)
+func (l AnyList[T]) IndexFunc(fn func(T) bool) int {
+	for i, v := range l {
+		if fn(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reverses the order of the list inplace.
+func (l AnyList[T]) Reverse() {
+	reverse(l)
+}
+
+// Returns a copy of the list, that doesn't share memory with the original. (The values themselves are not copied,
+// so if they are pointers or contain slices or maps, those are shared.)
+func (l AnyList[T]) Clone() AnyList[T] {
+	if l == nil {
+		return nil
+	}
+	return append(make(AnyList[T], 0, len(l)), l...)
+}
+
 // Entirely identical to len(list)
 func (l AnyList[T]) Len() int {
 	return len(l)
@@ -35,6 +116,22 @@ func (l AnyList[T]) Foreach(fn func(T)) {
 	}
 }
 
+// Returns a Seq2 of the index and value of each item in the list, which unlike Foreach can stop early. (See Seq)
+func (l AnyList[T]) All() Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range l {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a Seq of the values in the list. (See Seq)
+func (l AnyList[T]) Values() Seq[T] {
+	return SeqOf(l)
+}
+
 func (l AnyList[T]) Reduce(fn func(T, any) any, initial any) any {
 	a := initial
 	for _, v := range l {
@@ -42,3 +139,27 @@ func (l AnyList[T]) Reduce(fn func(T, any) any, initial any) any {
 	}
 	return a
 }
+
+// Sorts the list inplace, using the less function (which must return true if a sorts before b). The sort is not
+// stable, see SortStable.
+func (l AnyList[T]) Sort(less func(a, b T) bool) {
+	sort.Slice(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Sorts the list inplace, like Sort, but values that are equal keep their original order.
+func (l AnyList[T]) SortStable(less func(a, b T) bool) {
+	sort.SliceStable(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Returns true if the list is sorted according to the less function.
+func (l AnyList[T]) IsSorted(less func(a, b T) bool) bool {
+	return sort.SliceIsSorted(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Searches a sorted list for val, returning it's index and true if it is found, or the index it would be inserted
+// at to keep the list sorted and false if not. As the values may not be comparable, a value is found if neither it
+// nor val is less than the other, and the index of the first such item is returned. The list must be sorted with
+// the same less function.
+func (l AnyList[T]) BinarySearch(val T, less func(a, b T) bool) (int, bool) {
+	return binarySearch(l, val, less)
+}
@@ -1,6 +1,8 @@
 package missing_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/zafnz/go-missing"
@@ -29,3 +31,58 @@ func TestAnyReduce(t *testing.T) {
 		t.Errorf("Oddly, 1+2+3+4+5+10 does not equal 25: %v", sum)
 	}
 }
+
+func TestAnyListMutations(t *testing.T) {
+	x := missing.AnyList[[]int]{{1}, {2}, {3}}
+	x.Insert(1, []int{9})
+	if len(x) != 4 || x[1][0] != 9 || x[2][0] != 2 {
+		t.Errorf("Insert is wrong: %v", x)
+	}
+	if err := x.InsertAt(5, []int{0}); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("InsertAt past the end didn't return ErrOutOfRange: %v", err)
+	}
+	if v, err := x.RemoveAt(1); err != nil || v[0] != 9 || len(x) != 3 {
+		t.Errorf("RemoveAt is wrong: %v %v", x, err)
+	}
+	if idx := x.IndexFunc(func(v []int) bool { return v[0] == 3 }); idx != 2 {
+		t.Errorf("IndexFunc is wrong: %d", idx)
+	}
+	if n := x.RemoveFunc(func(v []int) bool { return v[0] > 1 }); n != 2 || len(x) != 1 {
+		t.Errorf("RemoveFunc is wrong: %v removed %d", x, n)
+	}
+	x.Append([]int{5})
+	y := x.Clone()
+	x.Reverse()
+	if x[0][0] != 5 || y[0][0] != 1 {
+		t.Errorf("Reverse or Clone is wrong: %v %v", x, y)
+	}
+	if v, ok := x.Pop(); !ok || v[0] != 1 {
+		t.Errorf("Pop is wrong: %v", v)
+	}
+	if v, ok := x.Shift(); !ok || v[0] != 5 || len(x) != 0 {
+		t.Errorf("Shift is wrong: %v", v)
+	}
+	if _, err := x.At(0); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("At on an empty list didn't return ErrOutOfRange: %v", err)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	type item struct {
+		key  int
+		name string
+	}
+	x := missing.AnyList[item]{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}}
+	byKey := func(a, b item) bool { return a.key < b.key }
+	x.SortStable(byKey)
+	if fmt.Sprint(x) != "[{1 b} {1 d} {2 a} {2 c}]" || !x.IsSorted(byKey) {
+		t.Errorf("SortStable is wrong: %v", x)
+	}
+	if i, found := x.BinarySearch(item{2, "z"}, byKey); i != 2 || !found {
+		t.Errorf("BinarySearch is wrong: %d %t", i, found)
+	}
+	x.Sort(func(a, b item) bool { return a.name > b.name })
+	if x[0].name != "d" {
+		t.Errorf("Sort is wrong: %v", x)
+	}
+}
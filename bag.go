@@ -0,0 +1,208 @@
+package missing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// A Bag (or multiset) is like a Set, but counts how many times each item has been added. Under the hood a bag is
+// simply a map of item to count:
+//   type Bag[T comparable] map[T]int
+// All the usual map functions will work, and len(bag) is the number of distinct items. Items are never stored with a
+// count of zero or less.
+//
+//   tags := missing.NewBag([]string{"go", "rust", "go"})
+//   tags.Add("go", 2)
+//   fmt.Println(tags.Count("go")) // 4
+type Bag[T comparable] map[T]int
+
+// One item in a bag and how many times it occurs, as returned by Bag.MostCommon.
+type BagCount[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Creates a new bag, counting the items in the provided slice (or List).
+func NewBag[T comparable](slice []T) Bag[T] {
+	b := make(Bag[T], len(slice))
+	b.AddSlice(slice)
+	return b
+}
+
+// Creates a new bag holding one of each item in the set.
+func NewBagFromSet[T comparable](set Set[T]) Bag[T] {
+	b := make(Bag[T], len(set))
+	for v := range set {
+		b[v] = 1
+	}
+	return b
+}
+
+// Adds n of the item to the bag. Adding zero or less does nothing.
+func (b *Bag[T]) Add(v T, n int) {
+	if n > 0 {
+		(*b)[v] += n
+	}
+}
+
+// Adds each item in the slice to the bag once (so items that appear more than once in the slice are counted more
+// than once).
+func (b *Bag[T]) AddSlice(slice []T) {
+	for _, v := range slice {
+		(*b)[v]++
+	}
+}
+
+// Removes n of the item from the bag. If that is as many as there are (or more), the item is removed entirely.
+func (b *Bag[T]) Remove(v T, n int) {
+	if n <= 0 {
+		return
+	}
+	if (*b)[v] <= n {
+		delete(*b, v)
+	} else {
+		(*b)[v] -= n
+	}
+}
+
+// Returns how many of the item are in the bag (0 if there are none).
+func (b Bag[T]) Count(v T) int {
+	return b[v]
+}
+
+// Returns true if there is at least one of the item in the bag.
+func (b Bag[T]) Contains(v T) bool {
+	return b[v] > 0
+}
+
+// Returns the total number of items in the bag, counting every copy. (len(bag) is the number of distinct items.)
+func (b Bag[T]) Length() int {
+	total := 0
+	for _, n := range b {
+		total += n
+	}
+	return total
+}
+
+// Returns a Seq2 of each distinct item in the bag and it's count, in no particular order. (See Seq)
+func (b Bag[T]) All() Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, n := range b {
+			if !yield(v, n) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the k most common items and their counts, most common first. Items with the same count are in no
+// particular order. If k is zero or less, or more than the number of distinct items, returns all of them.
+func (b Bag[T]) MostCommon(k int) []BagCount[T] {
+	counts := make([]BagCount[T], 0, len(b))
+	for v, n := range b {
+		counts = append(counts, BagCount[T]{v, n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Count > counts[j].Count
+	})
+	if k > 0 && k < len(counts) {
+		counts = counts[:k]
+	}
+	return counts
+}
+
+// Returns the union of bags a and b: every item, with the larger of it's two counts.
+func (a Bag[T]) Union(b Bag[T]) Bag[T] {
+	union := make(Bag[T], len(a))
+	for v, n := range a {
+		union[v] = n
+	}
+	for v, n := range b {
+		if n > union[v] {
+			union[v] = n
+		}
+	}
+	return union
+}
+
+// Returns the intersection of bags a and b: the items in both, with the smaller of their two counts.
+func (a Bag[T]) Intersection(b Bag[T]) Bag[T] {
+	intersection := make(Bag[T])
+	for v, n := range a {
+		if m := b[v]; m > 0 {
+			intersection[v] = If(n < m, n, m)
+		}
+	}
+	return intersection
+}
+
+// Returns the difference of bags a and b (a - b): each item's count in a, less it's count in b. Items with nothing
+// left are dropped.
+func (a Bag[T]) Difference(b Bag[T]) Bag[T] {
+	diff := make(Bag[T], len(a))
+	for v, n := range a {
+		if n > b[v] {
+			diff[v] = n - b[v]
+		}
+	}
+	return diff
+}
+
+// Returns the sum of bags a and b: every item, with it's two counts added together.
+func (a Bag[T]) Sum(b Bag[T]) Bag[T] {
+	sum := make(Bag[T], len(a))
+	for v, n := range a {
+		sum[v] = n
+	}
+	for v, n := range b {
+		sum[v] += n
+	}
+	return sum
+}
+
+// Returns the distinct items in the bag as a Set.
+func (b Bag[T]) ToSet() Set[T] {
+	set := make(Set[T], len(b))
+	for v := range b {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// Returns the items in the bag as a List, with each item repeated as many times as it's count. The order is not
+// defined, other than copies of an item being next to each other.
+func (b Bag[T]) ToList() List[T] {
+	list := make(List[T], 0, b.Length())
+	for v, n := range b {
+		for i := 0; i < n; i++ {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// A string representation of the bag (formated as a map of item to count)
+func (b Bag[T]) String() string {
+	return fmt.Sprint(map[T]int(b))
+}
+
+// A bag marshalls into a json object of item to count. As with any map, the item type must be a string, integer or
+// implement encoding.TextMarshaler.
+func (b Bag[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[T]int(b))
+}
+
+// A bag unmarshalls from a json object of item to count. Counts of zero or less are ignored.
+func (b *Bag[T]) UnmarshalJSON(data []byte) error {
+	var counts map[T]int
+	err := json.Unmarshal(data, &counts)
+	if err != nil {
+		return err
+	}
+	*b = make(Bag[T], len(counts))
+	for v, n := range counts {
+		b.Add(v, n)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleBag_MostCommon() {
+	words := missing.NewBag([]string{"the", "cat", "sat", "on", "the", "mat", "the", "cat"})
+	for _, c := range words.MostCommon(2) {
+		fmt.Println(c.Value, c.Count)
+	}
+	// Output:
+	// the 3
+	// cat 2
+}
+
+func TestBag(t *testing.T) {
+	b := missing.NewBag([]string{"a", "b", "a"})
+	b.Add("c", 3)
+	b.Add("d", 0)
+	if b.Count("a") != 2 || b.Count("c") != 3 || b.Count("d") != 0 || b.Contains("d") {
+		t.Errorf("Bag counts are wrong: %v", b)
+	}
+	if b.Length() != 6 || len(b) != 3 {
+		t.Errorf("Bag lengths are wrong: %d %d", b.Length(), len(b))
+	}
+	b.Remove("c", 2)
+	if b.Count("c") != 1 {
+		t.Errorf("Remove didn't reduce count: %v", b)
+	}
+	b.Remove("c", 5)
+	if b.Contains("c") || len(b) != 2 {
+		t.Errorf("Removing more than the count didn't remove the item: %v", b)
+	}
+
+	list := b.ToList()
+	sort.Strings(list)
+	if fmt.Sprint(list) != "[a a b]" {
+		t.Errorf("ToList is wrong: %v", list)
+	}
+	if set := b.ToSet(); len(set) != 2 || !set.Contains("a") || !set.Contains("b") {
+		t.Errorf("ToSet is wrong: %v", set)
+	}
+	if from := missing.NewBag(list); from.Count("a") != 2 {
+		t.Errorf("NewBag from a List is wrong: %v", from)
+	}
+	from := missing.NewBagFromSet(missing.NewSet([]string{"x", "y"}))
+	if len(from) != 2 || from.Count("x") != 1 || from.Count("y") != 1 || from.Length() != 2 {
+		t.Errorf("NewBagFromSet is wrong: %v", from)
+	}
+}
+
+func TestBagAlgebra(t *testing.T) {
+	a := missing.Bag[string]{"x": 3, "y": 1}
+	b := missing.Bag[string]{"x": 1, "y": 2, "z": 1}
+
+	if u := a.Union(b).String(); u != "map[x:3 y:2 z:1]" {
+		t.Errorf("Union is wrong: %s", u)
+	}
+	if i := a.Intersection(b).String(); i != "map[x:1 y:1]" {
+		t.Errorf("Intersection is wrong: %s", i)
+	}
+	if d := a.Difference(b).String(); d != "map[x:2]" {
+		t.Errorf("Difference is wrong: %s", d)
+	}
+	if s := a.Sum(b).String(); s != "map[x:4 y:3 z:1]" {
+		t.Errorf("Sum is wrong: %s", s)
+	}
+}
+
+func TestBagJson(t *testing.T) {
+	a := missing.Bag[string]{"x": 3, "y": 1}
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"x":3,"y":1}` {
+		t.Errorf("Bag didn't marshal to an object of counts: %s", bytes)
+	}
+	var b missing.Bag[string]
+	if err := json.Unmarshal([]byte(`{"x":3,"y":0,"z":-1}`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 1 || b.Count("x") != 3 {
+		t.Errorf("Bag didn't unmarshal: %v", b)
+	}
+}
@@ -0,0 +1,193 @@
+package missing
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// A BitSet is a set of small non-negative integers, such as permission flags or feature IDs, with the same methods
+// as Set. Under the hood it is simply a slice of bits:
+//   type BitSet []uint64
+// where bit n of the slice is set if n is in the set. This makes it far smaller and faster than a Set[int] when the
+// numbers are small, as the set operations work on 64 numbers at a time. The memory used depends on the largest
+// number in the set, so don't use it for large or sparse numbers.
+//
+//   perms := missing.NewBitSet([]int{Read, Write})
+//   if perms.Contains(Write) { ... }
+type BitSet []uint64
+
+// Creates a new bitset, using the provided slice.
+func NewBitSet(slice []int) BitSet {
+	var s BitSet
+	s.Add(slice...)
+	return s
+}
+
+// Returns true if the set contains the provided value.
+func (s BitSet) Contains(v int) bool {
+	word, bit := v/64, uint(v%64)
+	return v >= 0 && word < len(s) && s[word]&(1<<bit) != 0
+}
+
+// Returns the number of items in the set.
+func (s BitSet) Length() int {
+	n := 0
+	for _, w := range s {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Add individual value(s) to the set. Panics if a value is negative, rather than silently losing it, as a negative
+// value is a bug in the caller (UnmarshalJSON returns an error instead). Contains and Remove accept negative values,
+// as there is nothing to lose, they simply aren't in the set.
+func (s *BitSet) Add(vals ...int) {
+	for _, v := range vals {
+		if v < 0 {
+			panic(fmt.Sprintf("missing: BitSet can't hold negative value %d", v))
+		}
+		word := v / 64
+		s.grow(word + 1)
+		(*s)[word] |= 1 << uint(v%64)
+	}
+}
+
+// Adds the records from the slice to this set inplace. Functionaly the same as s.Add(slice...)
+func (s *BitSet) AddSlice(slice []int) {
+	s.Add(slice...)
+}
+
+// Adds the values from the supplied set to this set. (inplace operation, c.f. Union)
+func (a *BitSet) AddSet(b BitSet) {
+	a.grow(len(b))
+	for i, w := range b {
+		(*a)[i] |= w
+	}
+}
+
+// Removes individual value(s) from the set. Values that aren't in the set are ignored.
+func (s *BitSet) Remove(vals ...int) {
+	for _, v := range vals {
+		if word := v / 64; v >= 0 && word < len(*s) {
+			(*s)[word] &^= 1 << uint(v%64)
+		}
+	}
+}
+
+// Returns the difference of this set and the provided set. (a - b)
+func (a BitSet) Difference(b BitSet) BitSet {
+	diff := make(BitSet, len(a))
+	for i, w := range a {
+		if i < len(b) {
+			w &^= b[i]
+		}
+		diff[i] = w
+	}
+	return diff.trim()
+}
+
+// Returns the union of set a + b.
+func (a BitSet) Union(b BitSet) BitSet {
+	union := make(BitSet, len(a))
+	copy(union, a)
+	union.AddSet(b)
+	return union
+}
+
+// Returns the intersection of sets a and b, that is to say only things that are in both 'a' and 'b'
+func (a BitSet) Intersection(b BitSet) BitSet {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	intersection := make(BitSet, len(a))
+	for i, w := range a {
+		intersection[i] = w & b[i]
+	}
+	return intersection.trim()
+}
+
+// Returns true if both sets contain exactly the same items.
+func (a BitSet) Equal(b BitSet) bool {
+	a, b = a.trim(), b.trim()
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Creates a copy of the set as a slice, in ascending order.
+func (s BitSet) ToSlice() []int {
+	list := make([]int, 0, s.Length())
+	for i, w := range s {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			list = append(list, i*64+bit)
+			w &^= 1 << uint(bit)
+		}
+	}
+	return list
+}
+
+// Returns a Seq of the numbers in the set, in ascending order. (See Seq)
+func (s BitSet) All() Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range s {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &^= 1 << uint(bit)
+			}
+		}
+	}
+}
+
+// A string representation of the set (a string formated list, in ascending order)
+func (s BitSet) String() string {
+	return fmt.Sprint(s.ToSlice())
+}
+
+// A bitset marshalls into a json array of it's numbers (not the raw bits).
+func (s BitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// A bitset unmarshalls from a json array of numbers.
+func (s *BitSet) UnmarshalJSON(b []byte) error {
+	var list []int
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	for _, v := range list {
+		if v < 0 {
+			return fmt.Errorf("missing: BitSet can't hold negative value %d", v)
+		}
+	}
+	*s = nil
+	s.Add(list...)
+	return nil
+}
+
+// Makes sure the set has at least n words.
+func (s *BitSet) grow(n int) {
+	if n > len(*s) {
+		*s = append(*s, make(BitSet, n-len(*s))...)
+	}
+}
+
+// Returns the set without any trailing empty words.
+func (s BitSet) trim() BitSet {
+	n := len(s)
+	for n > 0 && s[n-1] == 0 {
+		n--
+	}
+	return s[:n]
+}
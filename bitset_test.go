@@ -0,0 +1,89 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestBitSet(t *testing.T) {
+	var s missing.BitSet
+	if s.Length() != 0 || s.Contains(0) {
+		t.Error("Empty BitSet isn't empty")
+	}
+	s.Add(0, 3, 63, 64, 200)
+	s.AddSlice([]int{3, 5})
+	if s.Length() != 6 {
+		t.Errorf("BitSet has the wrong length: %d", s.Length())
+	}
+	for _, v := range []int{0, 3, 5, 63, 64, 200} {
+		if !s.Contains(v) {
+			t.Errorf("BitSet doesn't contain %d", v)
+		}
+	}
+	if s.Contains(1) || s.Contains(65) || s.Contains(1000) || s.Contains(-1) {
+		t.Error("BitSet contains something it shouldn't")
+	}
+	if s.String() != "[0 3 5 63 64 200]" {
+		t.Errorf("BitSet ToSlice is wrong: %v", s)
+	}
+	s.Remove(64, 200, 1000, -5)
+	if s.String() != "[0 3 5 63]" {
+		t.Errorf("BitSet Remove is wrong: %v", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Adding a negative value didn't panic")
+		}
+	}()
+	s.Add(-1)
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	a := missing.NewBitSet([]int{1, 2, 3, 100})
+	b := missing.NewBitSet([]int{2, 3, 4})
+	if u := a.Union(b); u.String() != "[1 2 3 4 100]" {
+		t.Errorf("Union is wrong: %v", u)
+	}
+	if i := a.Intersection(b); i.String() != "[2 3]" || len(i) != 1 {
+		t.Errorf("Intersection is wrong: %v (%d words)", i, len(i))
+	}
+	if d := a.Difference(b); d.String() != "[1 100]" {
+		t.Errorf("Difference is wrong: %v", d)
+	}
+	if d := b.Difference(a); d.String() != "[4]" {
+		t.Errorf("Difference is wrong: %v", d)
+	}
+	// Sets with different numbers of words can still be equal
+	c := missing.NewBitSet([]int{1, 200})
+	c.Remove(200)
+	if !c.Equal(missing.NewBitSet([]int{1})) || c.Equal(a) {
+		t.Error("Equal is wrong")
+	}
+	if a.String() != "[1 2 3 100]" {
+		t.Errorf("Set operations changed the original: %v", a)
+	}
+}
+
+func TestBitSetJson(t *testing.T) {
+	a := missing.NewBitSet([]int{70, 5, 1})
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != "[1,5,70]" {
+		t.Errorf("BitSet didn't marshal to an array: %s", bytes)
+	}
+	var b missing.BitSet
+	if err := json.Unmarshal(bytes, &b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Equal(b) {
+		t.Errorf("BitSet didn't unmarshal: %v", b)
+	}
+	if err := json.Unmarshal([]byte("[1,-2]"), &b); err == nil {
+		t.Error("Unmarshalling a negative number didn't error")
+	}
+}
@@ -0,0 +1,355 @@
+package missing
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Options for NewCache. The zero value is a cache with no size limit whose entries never expire.
+type CacheOptions[K comparable, V any] struct {
+	MaxSize int           // The most entries to hold, the least recently used are evicted beyond this. 0 is no limit.
+	TTL     time.Duration // How long entries live for, unless set with SetTTL. 0 is forever.
+	// Called after an entry leaves the cache, other than by being replaced with Set. It is called without the
+	// cache's lock held, so it is safe to use the cache inside it.
+	OnEvict func(key K, val V, reason EvictReason)
+}
+
+// Why an entry left a Cache, passed to CacheOptions.OnEvict.
+type EvictReason int
+
+const (
+	EvictedCapacity EvictReason = iota // The cache was full, and this was the least recently used entry
+	EvictedExpired                     // The entry's TTL passed
+	EvictedDeleted                     // The entry was removed with Delete or Clear
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictedCapacity:
+		return "capacity"
+	case EvictedExpired:
+		return "expired"
+	case EvictedDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// Hit and miss counts for a Cache, returned by Stats.
+type CacheStats struct {
+	Hits      int64 // Gets (and GetOrLoads) that found a value
+	Misses    int64 // Gets (and GetOrLoads) that didn't
+	Evictions int64 // Entries removed because the cache was full, or they expired
+}
+
+// A Cache is a map with a maximum size, that evicts the least recently used entries once it's full, and
+// optionally expires entries after a time to live (TTL). It is safe to use from multiple goroutines.
+//
+// GetOrLoad memoises a slow function, such as a database query or one wrapped with TimeoutFnErr. If several
+// goroutines ask for the same missing key at once, the function is only called once and they all get the result.
+//
+// Expired entries are removed lazily, when they are next looked at or when room is needed, so an expired entry
+// may still be counted by Len until then. Call Purge to remove them all immediately.
+//
+//   users := missing.NewCache(missing.CacheOptions[int, *User]{MaxSize: 1000, TTL: time.Minute})
+//   user, err := users.GetOrLoad(id, func() (*User, error) {
+//   	return missing.TimeoutFnErr(time.Second, func() (*User, error) { return db.LoadUser(id) })
+//   })
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	opts    CacheOptions[K, V]
+	entries map[K]*cacheEntry[K, V]
+	root    cacheEntry[K, V] // root.next is the most recently used entry, root.prev the least
+	loads   map[K]*cacheLoad[V]
+	stats   CacheStats
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key        K
+	val        V
+	expires    time.Time // Zero if it never expires
+	prev, next *cacheEntry[K, V]
+}
+
+// A GetOrLoad call in progress, that other callers for the same key wait on.
+type cacheLoad[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// An entry that has been removed from the cache, waiting for OnEvict to be called once the lock is released.
+type cacheEviction[K comparable, V any] struct {
+	key    K
+	val    V
+	reason EvictReason
+}
+
+// Creates an empty cache using the options.
+func NewCache[K comparable, V any](opts CacheOptions[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		opts:    opts,
+		entries: make(map[K]*cacheEntry[K, V]),
+		loads:   make(map[K]*cacheLoad[V]),
+	}
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	return c
+}
+
+// Returns the value for the key, and marks it as recently used. Returns false if the key isn't in the cache, or
+// has expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	val, ok, evicted := c.get(key)
+	c.mu.Unlock()
+	c.evicted(evicted)
+	return val, ok
+}
+
+// Returns the value for the key, without marking it as recently used or counting towards the stats. Returns
+// false if the key isn't in the cache, or has expired.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || e.expired(time.Now()) {
+		var zero V
+		return zero, false
+	}
+	return e.val, true
+}
+
+// Returns true if the key is in the cache and hasn't expired. Doesn't mark it as recently used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Sets the value for the key, using the cache's default TTL, and marks it as recently used. If the cache is full
+// the least recently used entry is evicted.
+func (c *Cache[K, V]) Set(key K, val V) {
+	c.SetTTL(key, val, c.opts.TTL)
+}
+
+// Sets the value for the key, expiring after ttl (0 is never) rather than the cache's default TTL.
+func (c *Cache[K, V]) SetTTL(key K, val V, ttl time.Duration) {
+	c.mu.Lock()
+	delete(c.loads, key) // A load already running would overwrite this with an older value
+	evicted := c.set(key, val, ttl)
+	c.mu.Unlock()
+	c.evicted(evicted)
+}
+
+// Returns the value for the key if it is in the cache. Otherwise calls load, and if it doesn't return an error
+// stores the value in the cache (with the default TTL) and returns it. Errors are returned but not cached.
+//
+// If other goroutines call GetOrLoad for the same key while load is running, they wait for it and get it's
+// result rather than calling load again. If load panics, they all get a *PanicError. If the key is Set, Deleted or
+// Cleared while load is running, it's result is still returned to those waiting for it but isn't stored, as it may
+// be out of date.
+func (c *Cache[K, V]) GetOrLoad(key K, load func() (V, error)) (V, error) {
+	c.mu.Lock()
+	val, ok, evicted := c.get(key)
+	if ok {
+		c.mu.Unlock()
+		c.evicted(evicted)
+		return val, nil
+	}
+	if l, ok := c.loads[key]; ok {
+		c.mu.Unlock()
+		c.evicted(evicted)
+		<-l.done
+		return l.val, l.err
+	}
+	l := &cacheLoad[V]{done: make(chan struct{})}
+	c.loads[key] = l
+	c.mu.Unlock()
+	c.evicted(evicted)
+
+	// Deferred, so that if load panics (with RecoverPanics off) those waiting are still released
+	panicked := true
+	defer func() {
+		if panicked {
+			l.err = &PanicError{Value: "missing: Cache load panicked", Stack: debug.Stack()}
+		}
+		c.mu.Lock()
+		var evicted []cacheEviction[K, V]
+		if c.loads[key] == l {
+			delete(c.loads, key)
+			if l.err == nil {
+				evicted = c.set(key, l.val, c.opts.TTL)
+			}
+		}
+		c.mu.Unlock()
+		close(l.done)
+		c.evicted(evicted)
+	}()
+	l.val, l.err = CatchPanic(load)
+	panicked = false
+	return l.val, l.err
+}
+
+// Removes the key from the cache. Returns false if it wasn't there.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	delete(c.loads, key)
+	e, ok := c.entries[key]
+	if ok {
+		c.unlink(e)
+	}
+	c.mu.Unlock()
+	if ok {
+		c.evicted([]cacheEviction[K, V]{{e.key, e.val, EvictedDeleted}})
+	}
+	return ok
+}
+
+// Removes every entry from the cache. The stats are kept.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	var evicted []cacheEviction[K, V]
+	for e := c.root.next; e != &c.root; e = e.next {
+		evicted = append(evicted, cacheEviction[K, V]{e.key, e.val, EvictedDeleted})
+	}
+	c.entries = make(map[K]*cacheEntry[K, V])
+	c.loads = make(map[K]*cacheLoad[V])
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	c.mu.Unlock()
+	c.evicted(evicted)
+}
+
+// Removes every expired entry from the cache, and returns how many there were.
+func (c *Cache[K, V]) Purge() int {
+	c.mu.Lock()
+	var evicted []cacheEviction[K, V]
+	now := time.Now()
+	for e := c.root.next; e != &c.root; {
+		next := e.next
+		if e.expired(now) {
+			evicted = append(evicted, c.evict(e, EvictedExpired))
+		}
+		e = next
+	}
+	c.mu.Unlock()
+	c.evicted(evicted)
+	return len(evicted)
+}
+
+// Returns the number of entries in the cache, which may include expired entries that haven't been removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Returns the keys in the cache, from most to least recently used. Expired entries are skipped.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.entries))
+	now := time.Now()
+	for e := c.root.next; e != &c.root; e = e.next {
+		if !e.expired(now) {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
+
+// Returns the hit, miss and eviction counts so far.
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Looks up the key, counting the hit or miss. The lock must be held.
+func (c *Cache[K, V]) get(key K) (val V, ok bool, evicted []cacheEviction[K, V]) {
+	e, ok := c.entries[key]
+	if ok && e.expired(time.Now()) {
+		evicted = append(evicted, c.evict(e, EvictedExpired))
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return val, false, evicted
+	}
+	c.stats.Hits++
+	c.moveToFront(e)
+	return e.val, true, evicted
+}
+
+// Stores the value, evicting entries if the cache is over it's size. The lock must be held.
+func (c *Cache[K, V]) set(key K, val V, ttl time.Duration) (evicted []cacheEviction[K, V]) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	if e, ok := c.entries[key]; ok {
+		e.val = val
+		e.expires = expires
+		c.moveToFront(e)
+		return nil
+	}
+	e := &cacheEntry[K, V]{key: key, val: val, expires: expires}
+	c.entries[key] = e
+	c.link(e)
+	if c.opts.MaxSize > 0 && len(c.entries) > c.opts.MaxSize {
+		// Expired entries are the first to go, before anything that is still valid
+		now := time.Now()
+		for e := c.root.prev; e != &c.root && len(c.entries) > c.opts.MaxSize; {
+			prev := e.prev
+			if e.expired(now) {
+				evicted = append(evicted, c.evict(e, EvictedExpired))
+			}
+			e = prev
+		}
+		for len(c.entries) > c.opts.MaxSize {
+			evicted = append(evicted, c.evict(c.root.prev, EvictedCapacity))
+		}
+	}
+	return evicted
+}
+
+// Removes the entry and counts the eviction. The lock must be held.
+func (c *Cache[K, V]) evict(e *cacheEntry[K, V], reason EvictReason) cacheEviction[K, V] {
+	c.unlink(e)
+	c.stats.Evictions++
+	return cacheEviction[K, V]{e.key, e.val, reason}
+}
+
+// Calls OnEvict for each evicted entry. The lock must not be held.
+func (c *Cache[K, V]) evicted(evicted []cacheEviction[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, ev := range evicted {
+		c.opts.OnEvict(ev.key, ev.val, ev.reason)
+	}
+}
+
+func (c *Cache[K, V]) link(e *cacheEntry[K, V]) {
+	e.prev = &c.root
+	e.next = c.root.next
+	c.root.next.prev = e
+	c.root.next = e
+}
+
+func (c *Cache[K, V]) unlink(e *cacheEntry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	delete(c.entries, e.key)
+}
+
+func (c *Cache[K, V]) moveToFront(e *cacheEntry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	c.link(e)
+}
+
+func (e *cacheEntry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
@@ -0,0 +1,203 @@
+package missing_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestCacheLRU(t *testing.T) {
+	var evicted []string
+	c := missing.NewCache(missing.CacheOptions[string, int]{
+		MaxSize: 3,
+		OnEvict: func(key string, val int, reason missing.EvictReason) {
+			evicted = append(evicted, fmt.Sprintf("%s=%d %v", key, val, reason))
+		},
+	})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a") // a is now the most recently used, so b goes next
+	c.Set("d", 4)
+	if c.Contains("b") || c.Len() != 3 {
+		t.Errorf("Least recently used wasn't evicted: %v", c.Keys())
+	}
+	if fmt.Sprint(c.Keys()) != "[d a c]" {
+		t.Errorf("Keys is wrong: %v", c.Keys())
+	}
+	if v, ok := c.Get("a"); v != 1 || !ok {
+		t.Errorf("Get is wrong: %d %v", v, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get of an evicted key returned ok")
+	}
+	c.Set("a", 10) // Replacing isn't an eviction
+	if !c.Delete("c") || c.Delete("c") {
+		t.Error("Delete is wrong")
+	}
+	if fmt.Sprint(evicted) != "[b=2 capacity c=3 deleted]" {
+		t.Errorf("OnEvict is wrong: %v", evicted)
+	}
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("Stats are wrong: %+v", stats)
+	}
+	c.Clear()
+	if c.Len() != 0 || len(evicted) != 4 {
+		t.Errorf("Clear is wrong: %v", evicted)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	var expired int32
+	c := missing.NewCache(missing.CacheOptions[string, int]{
+		TTL: 20 * time.Millisecond,
+		OnEvict: func(key string, val int, reason missing.EvictReason) {
+			if reason == missing.EvictedExpired {
+				atomic.AddInt32(&expired, 1)
+			}
+		},
+	})
+	c.Set("short", 1)
+	c.Set("other", 2)
+	c.SetTTL("forever", 3, 0)
+	if _, ok := c.Get("short"); !ok {
+		t.Error("Entry expired too soon")
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Error("Entry didn't expire")
+	}
+	if _, ok := c.Peek("forever"); !ok {
+		t.Error("Entry without a TTL expired")
+	}
+	if n := c.Purge(); n != 1 {
+		t.Errorf("Purge removed %d entries", n)
+	}
+	if c.Len() != 1 || atomic.LoadInt32(&expired) != 2 {
+		t.Errorf("Expired entries weren't removed: %v %d", c.Keys(), expired)
+	}
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	c := missing.NewCache(missing.CacheOptions[int, string]{})
+	var calls int32
+	release := make(chan struct{})
+	load := func() (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "loaded", nil
+	}
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.GetOrLoad(1, load)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Concurrent loads weren't coalesced: %d calls", calls)
+	}
+	for _, r := range results {
+		if r != "loaded" {
+			t.Errorf("GetOrLoad result is wrong: %v", results)
+			break
+		}
+	}
+	if v, ok := c.Get(1); v != "loaded" || !ok {
+		t.Errorf("Loaded value wasn't cached: %v", v)
+	}
+
+	failed := errors.New("failed")
+	if _, err := c.GetOrLoad(2, func() (string, error) { return "", failed }); err != failed {
+		t.Errorf("GetOrLoad error is wrong: %v", err)
+	}
+	if c.Contains(2) {
+		t.Error("Error was cached")
+	}
+	_, err := c.GetOrLoad(3, func() (string, error) { panic("oops") })
+	var perr *missing.PanicError
+	if !errors.As(err, &perr) {
+		t.Errorf("GetOrLoad panic wasn't returned as an error: %v", err)
+	}
+}
+
+func TestCacheGetOrLoadStale(t *testing.T) {
+	c := missing.NewCache(missing.CacheOptions[string, int]{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan int)
+	go func() {
+		v, _ := c.GetOrLoad("k", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		done <- v
+	}()
+	<-started
+	c.Set("k", 2)
+	close(release)
+	if v := <-done; v != 1 {
+		t.Errorf("GetOrLoad didn't return it's loaded value: %d", v)
+	}
+	if v, _ := c.Get("k"); v != 2 {
+		t.Errorf("A stale load overwrote Set: %d", v)
+	}
+
+	c.Delete("k")
+	started = make(chan struct{})
+	release = make(chan struct{})
+	go func() {
+		v, _ := c.GetOrLoad("k", func() (int, error) {
+			close(started)
+			<-release
+			return 3, nil
+		})
+		done <- v
+	}()
+	<-started
+	c.Delete("k")
+	close(release)
+	<-done
+	if c.Contains("k") {
+		t.Error("A stale load overwrote Delete")
+	}
+}
+
+func TestCacheGetOrLoadPanic(t *testing.T) {
+	missing.RecoverPanics(false)
+	defer missing.RecoverPanics(true)
+	c := missing.NewCache(missing.CacheOptions[string, int]{})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("load didn't panic with RecoverPanics off")
+			}
+		}()
+		c.GetOrLoad("k", func() (int, error) { panic("oops") })
+	}()
+	result := make(chan int)
+	go func() {
+		v, _ := c.GetOrLoad("k", func() (int, error) { return 1, nil })
+		result <- v
+	}()
+	select {
+	case v := <-result:
+		if v != 1 {
+			t.Errorf("GetOrLoad after a panic is wrong: %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetOrLoad blocked after an earlier load panicked")
+	}
+}
@@ -0,0 +1,194 @@
+package missing
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// A Deque (double ended queue) is a list that can have values added and removed at either end in O(1) (amortised)
+// time, and indexed in O(1). Compare to a List, where Prepend and Shift have to move every value. Underneath it is a
+// ring buffer that grows as needed.
+//
+// The zero value is an empty deque ready to use. Like a Set it json encodes as an array, from front to back, even
+// when held by value (eg in a struct).
+//
+//   var window missing.Deque[float64]
+//   window.PushBack(reading)
+//   if window.Len() > 10 {
+//   	window.PopFront()
+//   }
+type Deque[T any] struct {
+	buf  []T // The capacity is always a power of 2, so indexes can wrap with a mask
+	head int // Index of the front value in buf
+	len  int
+}
+
+// Creates a new deque, holding the values of the provided slice (the first value at the front).
+func NewDeque[T any](slice []T) *Deque[T] {
+	d := &Deque[T]{}
+	d.PushBack(slice...)
+	return d
+}
+
+// Returns the number of values in the deque.
+func (d Deque[T]) Len() int {
+	return d.len
+}
+
+// Adds the values to the back of the deque, in order.
+func (d *Deque[T]) PushBack(vals ...T) {
+	d.grow(len(vals))
+	for _, v := range vals {
+		d.buf[d.index(d.len)] = v
+		d.len++
+	}
+}
+
+// Adds the values to the front of the deque, so they end up in the order given before the existing values (the
+// same as List.Prepend).
+func (d *Deque[T]) PushFront(vals ...T) {
+	d.grow(len(vals))
+	for i := len(vals) - 1; i >= 0; i-- {
+		d.head = (d.head - 1) & (len(d.buf) - 1)
+		d.buf[d.head] = vals[i]
+		d.len++
+	}
+}
+
+// Removes the value at the front of the deque and returns it, or returns false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // Don't keep a reference to whatever was there
+	d.head = d.index(1)
+	d.len--
+	return v, true
+}
+
+// Removes the value at the back of the deque and returns it, or returns false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	i := d.index(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	return v, true
+}
+
+// Returns the value at the front of the deque without removing it, or false if the deque is empty.
+func (d Deque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Returns the value at the back of the deque without removing it, or false if the deque is empty.
+func (d Deque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// Returns the value at the specified index (0 is the front), or an ErrOutOfRange error if there isn't one.
+func (d Deque[T]) At(index int) (T, error) {
+	if index < 0 || index >= d.len {
+		var zero T
+		return zero, outOfRange(index, d.len)
+	}
+	return d.buf[d.index(index)], nil
+}
+
+// Replaces the value at the specified index (0 is the front), or returns an ErrOutOfRange error if there isn't one.
+func (d *Deque[T]) Set(index int, v T) error {
+	if index < 0 || index >= d.len {
+		return outOfRange(index, d.len)
+	}
+	d.buf[d.index(index)] = v
+	return nil
+}
+
+// Removes every value from the deque.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// Returns a Seq of the values in the deque, from front to back. (See Seq)
+func (d Deque[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Creates a copy of the deque as a slice, from front to back.
+func (d Deque[T]) ToSlice() []T {
+	list := make([]T, d.len)
+	for i := range list {
+		list[i] = d.buf[d.index(i)]
+	}
+	return list
+}
+
+// A string representation of the deque (a string formated list, from front to back)
+func (d Deque[T]) String() string {
+	return fmt.Sprint(d.ToSlice())
+}
+
+// A deque marshalls into a json array, from front to back.
+func (d Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToSlice())
+}
+
+// A deque unmarshalls from a json array, the first value becoming the front.
+func (d *Deque[T]) UnmarshalJSON(b []byte) error {
+	var list []T
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	d.Clear()
+	d.PushBack(list...)
+	return nil
+}
+
+// Returns the index into buf of the i'th value.
+func (d Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// Makes sure there is room for n more values, doubling the buffer (and unwrapping it) if not.
+func (d *Deque[T]) grow(n int) {
+	if d.len+n <= len(d.buf) {
+		return
+	}
+	size := If(len(d.buf) == 0, 8, len(d.buf))
+	for size < d.len+n {
+		size *= 2
+	}
+	buf := make([]T, size)
+	// Copy the values to the start of the new buffer, in order.
+	if d.len > 0 {
+		tail := d.index(d.len)
+		if d.head < tail {
+			copy(buf, d.buf[d.head:tail])
+		} else {
+			c := copy(buf, d.buf[d.head:])
+			copy(buf[c:], d.buf[:tail])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
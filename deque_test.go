@@ -0,0 +1,106 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestDeque(t *testing.T) {
+	var d missing.Deque[int]
+	if _, ok := d.PopFront(); ok || d.Len() != 0 {
+		t.Error("Zero value Deque isn't empty")
+	}
+	d.PushBack(3, 4)
+	d.PushFront(1, 2)
+	if d.String() != "[1 2 3 4]" || d.Len() != 4 {
+		t.Errorf("Push is wrong: %v", d.String())
+	}
+	if v, _ := d.Front(); v != 1 {
+		t.Errorf("Front is wrong: %d", v)
+	}
+	if v, _ := d.Back(); v != 4 {
+		t.Errorf("Back is wrong: %d", v)
+	}
+	if v, ok := d.PopFront(); v != 1 || !ok {
+		t.Errorf("PopFront is wrong: %d", v)
+	}
+	if v, ok := d.PopBack(); v != 4 || !ok {
+		t.Errorf("PopBack is wrong: %d", v)
+	}
+	if v, err := d.At(1); v != 3 || err != nil {
+		t.Errorf("At(1) is wrong: %d %v", v, err)
+	}
+	if _, err := d.At(2); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("At past the end didn't return ErrOutOfRange: %v", err)
+	}
+	if err := d.Set(0, 20); err != nil || d.String() != "[20 3]" {
+		t.Errorf("Set is wrong: %v %v", d.String(), err)
+	}
+	d.Clear()
+	if d.Len() != 0 {
+		t.Error("Clear didn't empty the deque")
+	}
+}
+
+// Push and pop across the wrap around point and through several grows, checking against a plain slice.
+func TestDequeWrap(t *testing.T) {
+	d := missing.NewDeque([]int{})
+	var check []int
+	for i := 0; i < 1000; i++ {
+		switch i % 5 {
+		case 0, 1:
+			d.PushBack(i)
+			check = append(check, i)
+		case 2:
+			d.PushFront(i)
+			check = append([]int{i}, check...)
+		case 3:
+			d.PopFront()
+			check = check[1:]
+		case 4:
+			if i%3 == 0 {
+				d.PopBack()
+				check = check[:len(check)-1]
+			}
+		}
+	}
+	if fmt.Sprint(d.ToSlice()) != fmt.Sprint(check) {
+		t.Fatalf("Deque doesn't match:\n%v\n%v", d.ToSlice(), check)
+	}
+	if fmt.Sprint(d.All().Take(3).Collect()) != fmt.Sprint(check[:3]) {
+		t.Errorf("All is wrong: %v", d.All().Take(3).Collect())
+	}
+}
+
+func TestDequeJson(t *testing.T) {
+	d := missing.NewDeque([]string{"b", "c"})
+	d.PushFront("a")
+	bytes, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `["a","b","c"]` {
+		t.Errorf("Deque didn't marshal to an array: %s", bytes)
+	}
+	var e missing.Deque[string]
+	if err := json.Unmarshal(bytes, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.String() != "[a b c]" {
+		t.Errorf("Deque didn't unmarshal: %v", e.String())
+	}
+
+	// Held by value in a struct
+	held := struct{ D missing.Deque[string] }{e}
+	bytes, err = json.Marshal(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"D":["a","b","c"]}` {
+		t.Errorf("Deque held by value didn't marshal: %s", bytes)
+	}
+}
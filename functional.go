@@ -0,0 +1,140 @@
+package missing
+
+import "sort"
+
+// Functional helpers that work on any slice, including List and AnyList. Go methods can't introduce new type
+// parameters, so these are package functions rather than methods. Functions that return the same kind of slice they
+// were given (Filter, Partition, Unique, etc) return the same type, so a List stays a List.
+
+// Two values of (possibly) different types, as returned by Zip.
+type Pair[T1, T2 any] struct {
+	V1 T1
+	V2 T2
+}
+
+// Returns a new slice holding the result of calling fn on each item of s, in order.
+//
+//   names := missing.Map(people, func(p Person) string { return p.Name })
+func Map[S ~[]T, T, U any](s S, fn func(T) U) []U {
+	result := make([]U, len(s))
+	for i, v := range s {
+		result[i] = fn(v)
+	}
+	return result
+}
+
+// Returns a new slice of only the items of s for which fn returns true, in order.
+//
+//   adults := missing.Filter(people, func(p Person) bool { return p.Age >= 18 })
+func Filter[S ~[]T, T any](s S, fn func(T) bool) S {
+	var result S
+	for _, v := range s {
+		if fn(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Calls fn on each item of s, and returns all the slices it returns joined together into one.
+//
+//   allOrders := missing.FlatMap(customers, func(c Customer) []Order { return c.Orders })
+func FlatMap[S ~[]T, T, U any](s S, fn func(T) []U) []U {
+	var result []U
+	for _, v := range s {
+		result = append(result, fn(v)...)
+	}
+	return result
+}
+
+// Combines the items of s into a single value, by calling fn with the value so far (starting with initial) and each
+// item in turn. Unlike List.Reduce the result is properly typed.
+//
+//   total := missing.Reduce(orders, 0.0, func(sum float64, o Order) float64 { return sum + o.Price })
+func Reduce[S ~[]T, T, A any](s S, initial A, fn func(A, T) A) A {
+	a := initial
+	for _, v := range s {
+		a = fn(a, v)
+	}
+	return a
+}
+
+// Groups the items of s by the key fn returns for them. Each group keeps the order of s.
+//
+//   byCity := missing.GroupBy(people, func(p Person) string { return p.City })
+func GroupBy[S ~[]T, T any, K comparable](s S, key func(T) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Splits s into the items for which fn returns true, and those for which it returns false, keeping their order.
+func Partition[S ~[]T, T any](s S, fn func(T) bool) (matched S, rest S) {
+	for _, v := range s {
+		if fn(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// Splits s into slices of size items (the last may be shorter). The chunks share memory with s, so changing an item
+// in a chunk changes it in s. Panics if size is less than 1.
+func Chunk[S ~[]T, T any](s S, size int) []S {
+	if size < 1 {
+		panic("missing: Chunk size must be at least 1")
+	}
+	chunks := make([]S, 0, (len(s)+size-1)/size)
+	for len(s) > size {
+		chunks = append(chunks, s[:size:size])
+		s = s[size:]
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// Pairs up the items of a and b by index. If one is longer than the other, it's extra items are ignored.
+func Zip[T1, T2 any](a []T1, b []T2) []Pair[T1, T2] {
+	n := If(len(a) < len(b), len(a), len(b))
+	pairs := make([]Pair[T1, T2], n)
+	for i := 0; i < n; i++ {
+		pairs[i] = Pair[T1, T2]{a[i], b[i]}
+	}
+	return pairs
+}
+
+// Returns a new slice of the items in s with duplicates removed, keeping the first of each.
+func Unique[S ~[]T, T comparable](s S) S {
+	seen := make(Set[T], len(s))
+	var result S
+	for _, v := range s {
+		if !seen.Contains(v) {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Sorts any slice of numbers or strings (including a List or AnyList) into ascending order, inplace.
+//
+//   list := missing.List[int]{3, 1, 2}
+//   missing.Sort(list) // [1 2 3]
+func Sort[S ~[]T, T Ordered](s S) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
+
+// Sorts any slice (including a List or AnyList) inplace, into ascending order of the key the function returns for
+// each value. The sort is stable, so values with the same key keep their original order.
+//
+//   missing.SortBy(people, func(p Person) string { return p.Name })
+func SortBy[S ~[]T, T any, K Ordered](s S, key func(T) K) {
+	sort.SliceStable(s, func(i, j int) bool { return key(s[i]) < key(s[j]) })
+}
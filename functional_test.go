@@ -0,0 +1,104 @@
+package missing_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleMap() {
+	list := missing.List[int]{1, 2, 3}
+	strs := missing.Map(list, func(i int) string {
+		return strings.Repeat("*", i)
+	})
+	fmt.Println(strs)
+	// Output: [* ** ***]
+}
+
+func ExampleReduce() {
+	words := []string{"the", "quick", "brown", "fox"}
+	letters := missing.Reduce(words, 0, func(total int, w string) int {
+		return total + len(w)
+	})
+	fmt.Println(letters)
+	// Output: 16
+}
+
+func TestFilter(t *testing.T) {
+	list := missing.List[int]{1, 2, 3, 4, 5, 6}
+	even := missing.Filter(list, func(i int) bool { return i%2 == 0 })
+	// Filter returns a List when given a List, so List methods work on the result
+	if !even.Contains(4) || even.Len() != 3 {
+		t.Errorf("Filter is wrong: %v", even)
+	}
+	if none := missing.Filter(list, func(i int) bool { return false }); len(none) != 0 {
+		t.Errorf("Filter of nothing isn't empty: %v", none)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	words := missing.AnyList[string]{"ab", "cd"}
+	letters := missing.FlatMap(words, func(s string) []rune { return []rune(s) })
+	if string(letters) != "abcd" {
+		t.Errorf("FlatMap is wrong: %v", letters)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	groups := missing.GroupBy(words, func(s string) byte { return s[0] })
+	if len(groups) != 3 || fmt.Sprint(groups['b']) != "[banana blueberry]" {
+		t.Errorf("GroupBy is wrong: %v", groups)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	small, big := missing.Partition(missing.List[int]{5, 50, 1, 10, 100}, func(i int) bool { return i < 10 })
+	if fmt.Sprint(small) != "[5 1]" || fmt.Sprint(big) != "[50 10 100]" {
+		t.Errorf("Partition is wrong: %v %v", small, big)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	chunks := missing.Chunk([]int{1, 2, 3, 4, 5, 6, 7}, 3)
+	if fmt.Sprint(chunks) != "[[1 2 3] [4 5 6] [7]]" {
+		t.Errorf("Chunk is wrong: %v", chunks)
+	}
+	// Appending to a chunk must not overwrite the next chunk
+	chunks[0] = append(chunks[0], 99)
+	if chunks[1][0] != 4 {
+		t.Errorf("Appending to a chunk overwrote the next: %v", chunks)
+	}
+	if empty := missing.Chunk([]int{}, 3); len(empty) != 0 {
+		t.Errorf("Chunk of nothing isn't empty: %v", empty)
+	}
+}
+
+func TestZip(t *testing.T) {
+	pairs := missing.Zip([]string{"a", "b", "c"}, []int{1, 2})
+	if len(pairs) != 2 || pairs[1].V1 != "b" || pairs[1].V2 != 2 {
+		t.Errorf("Zip is wrong: %v", pairs)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	u := missing.Unique(missing.List[int]{3, 1, 3, 2, 1})
+	if fmt.Sprint(u) != "[3 1 2]" {
+		t.Errorf("Unique is wrong: %v", u)
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	x := missing.List[float64]{3.5, -1, 2}
+	missing.Sort(x)
+	if fmt.Sprint(x) != "[-1 2 3.5]" {
+		t.Errorf("Sort is wrong: %v", x)
+	}
+	words := []string{"banana", "Apple", "cherry", "fig"}
+	missing.SortBy(words, func(s string) int { return len(s) })
+	if fmt.Sprint(words) != "[fig Apple banana cherry]" {
+		t.Errorf("SortBy is wrong: %v", words)
+	}
+}
@@ -1,12 +1,22 @@
 package missing
 
 import (
+	"context"
 	"os"
+	"sync/atomic"
 	"time"
 )
 
 // Generic functions that are missing in go, and clearly needed.
 
+// A constraint for any type that can be compared with <, such as numbers and strings. (The same as
+// golang.org/x/exp/constraints.Ordered, without the dependency.)
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
 // Returns true if the provided slice (which can be any kind of slice, array, missing.List, etc) contains
 // the provided value. Is an O(n) search. (You may find the missing.List or missing.Set a better choice)
 func InSlice[T comparable](slice []T, find T) bool {
@@ -42,6 +52,8 @@ func If[T any](cmp bool, trueVal T, falseVal T) T {
 // a go routine thread forever. This is a limitation (or a feature) of go. If you want to support some kind of
 // termination ability, then use context.Contexts and signal with those! Almost all built-in libraries that
 // can take then (eg http, net, etc) support taking a ctx. You probably don't need this function at that point.
+// When the function does eventually return, it's result is handed to the OnAbandoned hook (if one is set) and
+// the goroutine exits. See AbandonedCalls to monitor how many of these are still running.
 //
 // Example -- ensure add a+b and ensure it happens within 1 second:
 //	 a := 42
@@ -93,22 +105,14 @@ func If[T any](cmp bool, trueVal T, falseVal T) T {
 //     time.Sleep(10 * time.Second)
 //     fmt.Println(str) // Outputs: "Timeout!"
 func TimeoutFn[T any](duration time.Duration, fn func() T) (T, error) {
-	ch := make(chan T)
-	go func() {
-		r := fn()
-		ch <- r
-	}()
-	select {
-	case ret := <-ch:
-		return ret, nil
-	case <-time.After(duration):
-		var r T
-		return r, os.ErrDeadlineExceeded
-	}
+	return TimeoutFnErr(duration, func() (T, error) {
+		return fn(), nil
+	})
 }
 
 // Similar to TimeoutFn, but the function you provide returns two values, one of them an error, and TimeoutFnErr
-// will return those two values. If the function times out, then err will be os.ErrDeadlineExceeded
+// will return those two values. If the function times out, then err will be os.ErrDeadlineExceeded. If the
+// function panics, then err will be a *PanicError (see RecoverPanics).
 //
 // See notes for TimeoutFn for important information.
 //
@@ -119,19 +123,113 @@ func TimeoutFn[T any](duration time.Duration, fn func() T) (T, error) {
 //     return resp, err
 //   })
 func TimeoutFnErr[T any](duration time.Duration, fn func() (T, error)) (T, error) {
-	ch := make(chan T)
-	errCh := make(chan error)
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	r, ok := call(ctx.Done(), fn)
+	if !ok {
+		return r.val, os.ErrDeadlineExceeded
+	}
+	return r.val, r.err
+}
+
+// Calls the supplied function, passing it a context derived from ctx, and returns it's return value. If ctx is
+// cancelled, or it's deadline passes, before the function returns then the unitialized value and ctx.Err() are
+// returned instead (context.Canceled or context.DeadlineExceeded respectively).
+//
+// The context passed to fn is cancelled as soon as TimeoutCtx returns, so a well behaved function can notice the
+// caller has given up and stop early. As with TimeoutFn, a function that ignores it's context will keep running
+// until it finishes, but it's result is simply discarded rather than blocking a goroutine forever.
+//
+// Example -- tie a slow calculation to the lifetime of a http request:
+//	 answer, err := TimeoutCtx(req.Context(), func(ctx context.Context) int {
+//	 	return DeepThought(ctx)
+//	 })
+//	 if err == context.Canceled {
+//	 	// The client went away, nobody is waiting for the answer.
+//	 }
+func TimeoutCtx[T any](ctx context.Context, fn func(context.Context) T) (T, error) {
+	return TimeoutCtxErr(ctx, func(ctx context.Context) (T, error) {
+		return fn(ctx), nil
+	})
+}
+
+// Similar to TimeoutCtx, but the function you provide returns two values, one of them an error, and
+// TimeoutCtxErr will return those two values. If ctx finishes first, then err will be ctx.Err().
+//
+// See notes for TimeoutCtx and TimeoutFn for important information.
+//
+//   ctx, cancel := context.WithTimeout(context.Background(), time.Second * 10)
+//   defer cancel()
+//   rows, err := TimeoutCtxErr(ctx, func(ctx context.Context) ([]Row, error) {
+//     return legacyDb.FetchAll(ctx)
+//   })
+func TimeoutCtxErr[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	r, ok := call(ctx.Done(), func() (T, error) {
+		return fn(ctx)
+	})
+	if !ok {
+		return r.val, ctx.Err()
+	}
+	return r.val, r.err
+}
+
+// Registers a function to be called with the result of any function that returns after the caller of TimeoutFn,
+// TimeoutFnErr, TimeoutCtx or TimeoutCtxErr has given up waiting for it. This gives you a chance to log late
+// results, or to clean up resources that would otherwise be leaked (TimeoutFn results have a nil error).
+// Passing nil removes the hook. The hook is called on the abandoned function's goroutine.
+//
+//   missing.OnAbandoned(func(val any, err error) {
+//   	if resp, ok := val.(*http.Response); ok && resp != nil {
+//   		resp.Body.Close()
+//   	}
+//   })
+func OnAbandoned(fn func(val any, err error)) {
+	abandonedHook.Store(fn)
+}
+
+// Returns the number of functions that have been given up on by TimeoutFn, TimeoutFnErr, TimeoutCtx or
+// TimeoutCtxErr, but have not yet returned. Each of these is a goroutine that is still running, so if this
+// number keeps growing you have functions that never finish.
+func AbandonedCalls() int64 {
+	return atomic.LoadInt64(&abandonedCalls)
+}
+
+var (
+	abandonedCalls int64
+	abandonedHook  atomic.Value // func(any, error)
+)
+
+// The return values of a function call, passed back over a channel.
+type result[T any] struct {
+	val T
+	err error
+}
+
+// Runs fn in a new goroutine and waits for it to return, or for giveUp to close. If we give up then ok is
+// false, and once fn does return it's result is handed to the OnAbandoned hook rather than blocking the
+// goroutine forever.
+func call[T any](giveUp <-chan struct{}, fn func() (T, error)) (r result[T], ok bool) {
+	ch := make(chan result[T])
+	abandon := make(chan struct{})
 	go func() {
-		val, err := fn()
-		ch <- val
-		errCh <- err
+		val, err := CatchPanic(fn)
+		select {
+		case ch <- result[T]{val, err}:
+		case <-abandon:
+			atomic.AddInt64(&abandonedCalls, -1)
+			if hook, _ := abandonedHook.Load().(func(any, error)); hook != nil {
+				hook(val, err)
+			}
+		}
 	}()
 	select {
-	case val := <-ch:
-		err := <-errCh
-		return val, err
-	case <-time.After(duration):
-		var r T
-		return r, os.ErrDeadlineExceeded
+	case r = <-ch:
+		return r, true
+	case <-giveUp:
+		atomic.AddInt64(&abandonedCalls, 1)
+		close(abandon)
+		return r, false
 	}
 }
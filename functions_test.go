@@ -1,6 +1,9 @@
 package missing_test
 
 import (
+	"context"
+	"errors"
+	"os"
 	"testing"
 	"time"
 
@@ -35,3 +38,91 @@ func TestTimeoutFn(t *testing.T) {
 	}
 
 }
+
+func TestTimeoutCtx(t *testing.T) {
+	c, err := missing.TimeoutCtx(context.Background(), func(ctx context.Context) int {
+		return 42 + 69
+	})
+	if err != nil {
+		t.Errorf("Got error when not expected: %s", err)
+	}
+	if c != 42+69 {
+		t.Error("Didn't sum it up correctly")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	fnCtx := make(chan context.Context, 1)
+	_, err = missing.TimeoutCtx(ctx, func(ctx context.Context) int {
+		fnCtx <- ctx
+		time.Sleep(time.Millisecond * 500)
+		return 0
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	// The context given to the function should have been cancelled once we gave up on it.
+	select {
+	case <-(<-fnCtx).Done():
+	case <-time.After(time.Millisecond * 100):
+		t.Error("Function's context was not cancelled after the timeout")
+	}
+}
+
+func TestTimeoutCtxErr(t *testing.T) {
+	blerg := errors.New("blerg")
+	_, err := missing.TimeoutCtxErr(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, blerg
+	})
+	if err != blerg {
+		t.Errorf("Function's error was not returned: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		cancel()
+	}()
+	_, err = missing.TimeoutCtxErr(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond * 50)
+		return 42, nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestTimeoutFnErrAbandoned(t *testing.T) {
+	// Let any functions abandoned by earlier tests finish, so we can count ours.
+	for i := 0; i < 100 && missing.AbandonedCalls() != 0; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	late := make(chan string, 1)
+	missing.OnAbandoned(func(val any, err error) {
+		if v, ok := val.(string); ok && v == "late" {
+			late <- v
+		}
+	})
+	defer missing.OnAbandoned(nil)
+
+	before := missing.AbandonedCalls()
+	_, err := missing.TimeoutFnErr(time.Millisecond*50, func() (string, error) {
+		time.Sleep(time.Millisecond * 200)
+		return "late", nil
+	})
+	if err != os.ErrDeadlineExceeded {
+		t.Errorf("Expected os.ErrDeadlineExceeded, got: %v", err)
+	}
+	if missing.AbandonedCalls() != before+1 {
+		t.Errorf("Abandoned call not counted: %d", missing.AbandonedCalls())
+	}
+	select {
+	case <-late:
+	case <-time.After(time.Second):
+		t.Fatal("OnAbandoned was not called with the late result")
+	}
+	if missing.AbandonedCalls() != before {
+		t.Errorf("Abandoned call still counted after it returned: %d", missing.AbandonedCalls())
+	}
+}
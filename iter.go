@@ -0,0 +1,208 @@
+package missing
+
+import "sync"
+
+// Iterators. Containers in this package have an All method that returns a Seq (or Seq2) of their values, which can
+// be consumed with a callback that can stop early, chained through lazy adapters (Filter, Take, MapSeq, etc) without
+// allocating intermediate slices, and collected back into a slice, List or Set.
+//
+// Seq and Seq2 have exactly the same shape as the standard library's iter.Seq and iter.Seq2, so from go 1.23 you can
+// range over them directly, and pass them to anything expecting an iter.Seq:
+//
+//   for v := range list.Values() { ... }
+//
+// On older versions of go, call them with a yield function instead (return false to stop early):
+//
+//   list.Values()(func(v int) bool {
+//   	fmt.Println(v)
+//   	return true
+//   })
+
+// A sequence of values. Calling it calls yield with each value in turn, until there are no more values or yield
+// returns false.
+type Seq[T any] func(yield func(T) bool)
+
+// A sequence of pairs of values, such as index and value, or key and value. Calling it calls yield with each pair in
+// turn, until there are no more or yield returns false.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// A pull style iterator: each call to Next returns the next value, or false once there are no more.
+type Iterator[T any] interface {
+	Next() (T, bool)
+}
+
+// Returns a Seq of the values of a slice (or List, or AnyList).
+func SeqOf[T any](s []T) Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a Seq that pulls each value from the Iterator until it runs out.
+func FromIterator[T any](it Iterator[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Converts a Seq into an Iterator, so values can be pulled from it one at a time. The Seq runs on it's own goroutine,
+// so if you stop before reaching the end you must call stop to release it. Calling stop more than once is fine.
+//
+//   it, stop := missing.Pull(set.All())
+//   defer stop()
+//   first, ok := it.Next()
+func Pull[T any](seq Seq[T]) (it Iterator[T], stop func()) {
+	p := &pullIterator[T]{values: make(chan T), stopped: make(chan struct{})}
+	go func() {
+		defer close(p.values)
+		seq(func(v T) bool {
+			select {
+			case p.values <- v:
+				return true
+			case <-p.stopped:
+				return false
+			}
+		})
+	}()
+	return p, func() {
+		p.once.Do(func() { close(p.stopped) })
+	}
+}
+
+type pullIterator[T any] struct {
+	values  chan T
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (p *pullIterator[T]) Next() (T, bool) {
+	select {
+	case v, ok := <-p.values:
+		return v, ok
+	case <-p.stopped:
+		var t T
+		return t, false
+	}
+}
+
+// Returns a Seq of only the values for which fn returns true.
+func (seq Seq[T]) Filter(fn func(T) bool) Seq[T] {
+	return func(yield func(T) bool) {
+		seq(func(v T) bool {
+			return !fn(v) || yield(v)
+		})
+	}
+}
+
+// Returns a Seq of (at most) the first n values.
+func (seq Seq[T]) Take(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		seq(func(v T) bool {
+			taken++
+			return yield(v) && taken < n
+		})
+	}
+}
+
+// Returns a Seq of the values after the first n.
+func (seq Seq[T]) Skip(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		seq(func(v T) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
+
+// Returns a Seq of this Seq's values, followed by the values of each of the others in turn.
+func (seq Seq[T]) Chain(others ...Seq[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		stopped := false
+		for _, s := range append([]Seq[T]{seq}, others...) {
+			s(func(v T) bool {
+				stopped = !yield(v)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// Calls fn for each value. The same as calling the Seq, for when fn never needs to stop early.
+func (seq Seq[T]) Foreach(fn func(T)) {
+	seq(func(v T) bool {
+		fn(v)
+		return true
+	})
+}
+
+// Collects every value into a slice.
+func (seq Seq[T]) Collect() []T {
+	var list []T
+	seq.Foreach(func(v T) {
+		list = append(list, v)
+	})
+	return list
+}
+
+// Returns a Seq of just the first value of each pair (eg the keys, or indexes).
+func (seq Seq2[K, V]) Keys() Seq[K] {
+	return func(yield func(K) bool) {
+		seq(func(k K, v V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Returns a Seq of just the second value of each pair.
+func (seq Seq2[K, V]) Values() Seq[V] {
+	return func(yield func(V) bool) {
+		seq(func(k K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Returns a Seq of the result of calling fn on each value. (This is a package function as go methods can't
+// introduce new type parameters.)
+//
+//   names := missing.MapSeq(people.Values(), func(p Person) string { return p.Name })
+func MapSeq[T, U any](seq Seq[T], fn func(T) U) Seq[U] {
+	return func(yield func(U) bool) {
+		seq(func(v T) bool {
+			return yield(fn(v))
+		})
+	}
+}
+
+// Collects every value into a List.
+func CollectList[T comparable](seq Seq[T]) List[T] {
+	return List[T](seq.Collect())
+}
+
+// Collects every value into a Set.
+func CollectSet[T comparable](seq Seq[T]) Set[T] {
+	set := Set[T]{}
+	seq.Foreach(func(v T) {
+		set[v] = struct{}{}
+	})
+	return set
+}
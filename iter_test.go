@@ -0,0 +1,112 @@
+package missing_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleSeq() {
+	list := missing.List[int]{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	odd := list.Values().Filter(func(i int) bool { return i%2 == 1 })
+	squares := missing.MapSeq(odd, func(i int) int { return i * i })
+	fmt.Println(squares.Skip(1).Take(3).Collect())
+	// Output: [9 25 49]
+}
+
+func TestSeqAdapters(t *testing.T) {
+	a := missing.SeqOf([]int{1, 2, 3})
+	b := missing.SeqOf([]int{4, 5})
+	if all := a.Chain(b).Collect(); fmt.Sprint(all) != "[1 2 3 4 5]" {
+		t.Errorf("Chain is wrong: %v", all)
+	}
+	if some := a.Chain(b).Take(4).Collect(); fmt.Sprint(some) != "[1 2 3 4]" {
+		t.Errorf("Chain didn't stop early: %v", some)
+	}
+	if none := a.Take(0).Collect(); len(none) != 0 {
+		t.Errorf("Take(0) isn't empty: %v", none)
+	}
+	if none := a.Skip(5).Collect(); len(none) != 0 {
+		t.Errorf("Skipping everything isn't empty: %v", none)
+	}
+
+	// Adapters are lazy, only the values needed are produced
+	produced := 0
+	counting := missing.MapSeq(missing.SeqOf([]int{1, 2, 3, 4, 5}), func(i int) int {
+		produced++
+		return i
+	})
+	counting.Take(2).Collect()
+	if produced != 2 {
+		t.Errorf("Take(2) produced %d values", produced)
+	}
+
+	list := missing.CollectList(a.Chain(a))
+	if !list.Equal(missing.List[int]{1, 2, 3, 1, 2, 3}) {
+		t.Errorf("CollectList is wrong: %v", list)
+	}
+	if set := missing.CollectSet(a.Chain(a)); !set.Equal(missing.NewSet([]int{1, 2, 3})) {
+		t.Errorf("CollectSet is wrong: %v", set)
+	}
+}
+
+func TestContainerSeqs(t *testing.T) {
+	var idxs []int
+	missing.List[string]{"a", "b", "c"}.All()(func(i int, v string) bool {
+		idxs = append(idxs, i)
+		return v != "b"
+	})
+	if fmt.Sprint(idxs) != "[0 1]" {
+		t.Errorf("List.All didn't stop early: %v", idxs)
+	}
+	anyList := missing.AnyList[int]{1, 2}
+	if v := anyList.All().Values().Collect(); fmt.Sprint(v) != "[1 2]" {
+		t.Errorf("AnyList.All is wrong: %v", v)
+	}
+	if v := missing.NewSet([]int{1, 2, 3}).All().Collect(); len(v) != 3 {
+		t.Errorf("Set.All is wrong: %v", v)
+	}
+	if v := missing.NewSyncSet([]int{1, 2, 3}).All().Collect(); len(v) != 3 {
+		t.Errorf("SyncSet.All is wrong: %v", v)
+	}
+	if v := missing.NewOrderedSet([]int{3, 1, 2}).All().Take(2).Collect(); fmt.Sprint(v) != "[3 1]" {
+		t.Errorf("OrderedSet.All is wrong: %v", v)
+	}
+	if v := missing.NewSortedSet([]int{3, 1, 2}).All().Take(2).Collect(); fmt.Sprint(v) != "[1 2]" {
+		t.Errorf("SortedSet.All is wrong: %v", v)
+	}
+	if v := missing.NewBitSet([]int{100, 3, 64}).All().Collect(); fmt.Sprint(v) != "[3 64 100]" {
+		t.Errorf("BitSet.All is wrong: %v", v)
+	}
+	total := 0
+	missing.NewBag([]string{"a", "a", "b"}).All()(func(v string, n int) bool {
+		total += n
+		return true
+	})
+	if total != 3 {
+		t.Errorf("Bag.All is wrong: %d", total)
+	}
+}
+
+func TestPull(t *testing.T) {
+	it, stop := missing.Pull(missing.SeqOf([]int{1, 2, 3}))
+	defer stop()
+	if v, ok := it.Next(); v != 1 || !ok {
+		t.Errorf("First Next is wrong: %d %t", v, ok)
+	}
+	if v := missing.FromIterator(it).Collect(); fmt.Sprint(v) != "[2 3]" {
+		t.Errorf("FromIterator didn't carry on from where Next was: %v", v)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("Next returned a value after the end")
+	}
+
+	it, stop = missing.Pull(missing.SeqOf([]int{1, 2, 3}))
+	it.Next()
+	stop()
+	stop()
+	if _, ok := it.Next(); ok {
+		t.Error("Next returned a value after stop")
+	}
+}
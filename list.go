@@ -1,7 +1,17 @@
 package missing
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
 // Treat slices as objects with methods.
 
+// The error returned by the bounds checked List and AnyList methods when the index is out of range. The error
+// returned also says what the index and length were, so test for it with errors.Is.
+var ErrOutOfRange = errors.New("index out of range")
+
 // A List can contain any comparable type (See `AnyList`` for lists that support any type) and has some useful
 // functions associated with it.
 type List[T comparable] []T
@@ -22,12 +32,114 @@ func (l *List[T]) Prepend(vals ...T) {
 	*l = append(vals, *l...)
 }
 
-// Inserts the supplied values into the list at the specified index.
+// Inserts the supplied values into the list at the specified index. Panics if the index is out of range (see
+// InsertAt for a version that returns an error instead).
 func (l *List[T]) Insert(index int, vals ...T) {
-	before := (*l)[0:index]
-	after := (*l)[index:]
-	*l = append(before, vals...)
-	*l = append(*l, after...)
+	*l = insert(*l, index, vals...)
+}
+
+// Inserts the supplied values into the list at the specified index, the same as Insert, but returns an
+// ErrOutOfRange error rather than panicking if the index isn't between 0 and Len() (inclusive).
+func (l *List[T]) InsertAt(index int, vals ...T) error {
+	if index < 0 || index > len(*l) {
+		return outOfRange(index, len(*l))
+	}
+	*l = insert(*l, index, vals...)
+	return nil
+}
+
+// Returns the value at the specified index, or an ErrOutOfRange error if there isn't one.
+func (l List[T]) At(index int) (T, error) {
+	if index < 0 || index >= len(l) {
+		var t T
+		return t, outOfRange(index, len(l))
+	}
+	return l[index], nil
+}
+
+// Removes the value at the specified index from the list and returns it, or returns an ErrOutOfRange error if there
+// isn't one.
+func (l *List[T]) RemoveAt(index int) (T, error) {
+	if index < 0 || index >= len(*l) {
+		var t T
+		return t, outOfRange(index, len(*l))
+	}
+	v := (*l)[index]
+	*l = remove(*l, index)
+	return v, nil
+}
+
+// Removes the first occurance of val from the list. Returns false if the list doesn't contain val.
+func (l *List[T]) RemoveValue(val T) bool {
+	idx := l.IndexOf(val)
+	if idx < 0 {
+		return false
+	}
+	*l = remove(*l, idx)
+	return true
+}
+
+// Removes every value from the list for which the function returns true, and returns how many were removed.
+func (l *List[T]) RemoveFunc(fn func(T) bool) int {
+	var removed int
+	*l, removed = removeFunc(*l, fn)
+	return removed
+}
+
+// Removes the last value from the list and returns it, or returns false if the list is empty.
+func (l *List[T]) Pop() (T, bool) {
+	return pop((*[]T)(l))
+}
+
+// Removes the first value from the list and returns it, or returns false if the list is empty.
+func (l *List[T]) Shift() (T, bool) {
+	return shift((*[]T)(l))
+}
+
+// Returns the index of the first occurance of val in the list, or -1 if it isn't in the list.
+func (l List[T]) IndexOf(val T) int {
+	for i, v := range l {
+		if v == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the index of the last occurance of val in the list, or -1 if it isn't in the list.
+func (l List[T]) LastIndexOf(val T) int {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i] == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reverses the order of the list inplace.
+func (l List[T]) Reverse() {
+	reverse(l)
+}
+
+// Returns a copy of the list, that doesn't share memory with the original.
+func (l List[T]) Clone() List[T] {
+	if l == nil {
+		return nil
+	}
+	return append(make(List[T], 0, len(l)), l...)
+}
+
+// Returns true if both lists have the same length, and the same values in the same order.
+func (l List[T]) Equal(b List[T]) bool {
+	if len(l) != len(b) {
+		return false
+	}
+	for i, v := range l {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // Entirely identical to len(list)
@@ -54,7 +166,24 @@ func (l List[T]) Foreach(fn func(T)) {
 	}
 }
 
-// A really complicated way to do a Reduce on a list. If you insist you can use it, but the go way is clearer
+// Returns a Seq2 of the index and value of each item in the list, which unlike Foreach can stop early. (See Seq)
+func (l List[T]) All() Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range l {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a Seq of the values in the list. (See Seq)
+func (l List[T]) Values() Seq[T] {
+	return SeqOf(l)
+}
+
+// A really complicated way to do a Reduce on a list. If you insist you can use it, but the go way is clearer (and
+// missing.Reduce is at least properly typed)
 //
 // Example if you insist on doing this:
 //    list := List[int]{1,2,3,4,5}
@@ -75,3 +204,109 @@ func (l List[T]) Reduce(fn func(T, any) any, initial any) any {
 	}
 	return a
 }
+
+// Sorts the list inplace, using the less function (which must return true if a sorts before b). The sort is not
+// stable, see SortStable.
+//
+//   people.Sort(func(a, b Person) bool { return a.Age < b.Age })
+func (l List[T]) Sort(less func(a, b T) bool) {
+	sort.Slice(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Sorts the list inplace, like Sort, but values that are equal keep their original order.
+func (l List[T]) SortStable(less func(a, b T) bool) {
+	sort.SliceStable(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Returns true if the list is sorted according to the less function.
+func (l List[T]) IsSorted(less func(a, b T) bool) bool {
+	return sort.SliceIsSorted(l, func(i, j int) bool { return less(l[i], l[j]) })
+}
+
+// Searches a sorted list for val, returning it's index and true if it is found, or the index it would be inserted
+// at to keep the list sorted and false if not. The list must be sorted with the same less function.
+//
+// A value is found if neither it nor val is less than the other, the same as AnyList.BinarySearch, so with a less
+// function that compares part of the value (eg a key), any item with the same key matches and the index of the
+// first of them is returned. Use IndexOf to find an exact value.
+func (l List[T]) BinarySearch(val T, less func(a, b T) bool) (int, bool) {
+	return binarySearch(l, val, less)
+}
+
+// Slice functions shared by List and AnyList.
+
+func outOfRange(index int, length int) error {
+	return fmt.Errorf("%w: %d with length %d", ErrOutOfRange, index, length)
+}
+
+// Finds the first item in the sorted slice that is equivalent to val (neither is less than the other), or where val
+// would be inserted.
+func binarySearch[T any](s []T, val T, less func(a, b T) bool) (int, bool) {
+	i := sort.Search(len(s), func(i int) bool { return !less(s[i], val) })
+	return i, i < len(s) && !less(val, s[i])
+}
+
+func insert[T any](s []T, index int, vals ...T) []T {
+	n := len(s)
+	if index < 0 || index > n {
+		panic(outOfRange(index, n))
+	}
+	if n+len(vals) <= cap(s) {
+		// The insert happens inplace, and vals could be part of s (or it's spare capacity), which moving the tail
+		// would overwrite, so work from a copy.
+		vals = append([]T(nil), vals...)
+	}
+	s = append(s, vals...)
+	copy(s[index+len(vals):], s[index:n])
+	copy(s[index:], vals)
+	return s
+}
+
+func remove[T any](s []T, index int) []T {
+	copy(s[index:], s[index+1:])
+	var zero T
+	s[len(s)-1] = zero // Don't keep a reference to whatever was there
+	return s[:len(s)-1]
+}
+
+func removeFunc[T any](s []T, fn func(T) bool) ([]T, int) {
+	kept := s[:0]
+	for _, v := range s {
+		if !fn(v) {
+			kept = append(kept, v)
+		}
+	}
+	var zero T
+	for i := len(kept); i < len(s); i++ {
+		s[i] = zero
+	}
+	return kept, len(s) - len(kept)
+}
+
+func pop[T any](s *[]T) (T, bool) {
+	var zero T
+	if len(*s) == 0 {
+		return zero, false
+	}
+	v := (*s)[len(*s)-1]
+	(*s)[len(*s)-1] = zero
+	*s = (*s)[:len(*s)-1]
+	return v, true
+}
+
+func shift[T any](s *[]T) (T, bool) {
+	var zero T
+	if len(*s) == 0 {
+		return zero, false
+	}
+	v := (*s)[0]
+	(*s)[0] = zero
+	*s = (*s)[1:]
+	return v, true
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -1,6 +1,8 @@
 package missing_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/zafnz/go-missing"
@@ -68,3 +70,157 @@ func TestForeach(t *testing.T) {
 		t.Errorf("Foreach did weird: %v", y)
 	}
 }
+
+func TestListInsertSharedCapacity(t *testing.T) {
+	// Insert must not overwrite values when the slice has spare capacity
+	x := make(missing.List[int], 4, 10)
+	copy(x, []int{1, 2, 3, 4})
+	x.Insert(2, 9)
+	if !x.Equal(missing.List[int]{1, 2, 9, 3, 4}) {
+		t.Errorf("Insert with spare capacity is wrong: %v", x)
+	}
+
+	// Or when the values being inserted are part of the list itself
+	y := make(missing.List[int], 3, 10)
+	copy(y, []int{1, 2, 3})
+	y.Insert(0, y[1:3]...)
+	if !y.Equal(missing.List[int]{2, 3, 1, 2, 3}) {
+		t.Errorf("Insert of the list's own values is wrong: %v", y)
+	}
+	z := make(missing.AnyList[int], 3, 10)
+	copy(z, []int{1, 2, 3})
+	if err := z.InsertAt(1, z[:3]...); err != nil || fmt.Sprint(z) != "[1 1 2 3 2 3]" {
+		t.Errorf("InsertAt of the list's own values is wrong: %v %v", z, err)
+	}
+}
+
+func TestListInsertAt(t *testing.T) {
+	x := missing.List[int]{1, 2, 3}
+	if err := x.InsertAt(3, 4, 5); err != nil || !x.Equal(missing.List[int]{1, 2, 3, 4, 5}) {
+		t.Errorf("InsertAt the end is wrong: %v %v", x, err)
+	}
+	if err := x.InsertAt(0, 0); err != nil || x[0] != 0 {
+		t.Errorf("InsertAt the start is wrong: %v %v", x, err)
+	}
+	if err := x.InsertAt(7, 1); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("InsertAt past the end didn't return ErrOutOfRange: %v", err)
+	}
+	if err := x.InsertAt(-1, 1); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("InsertAt -1 didn't return ErrOutOfRange: %v", err)
+	}
+	if v, err := x.At(2); v != 2 || err != nil {
+		t.Errorf("At(2) is wrong: %d %v", v, err)
+	}
+	if _, err := x.At(6); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("At past the end didn't return ErrOutOfRange: %v", err)
+	}
+}
+
+func TestListRemove(t *testing.T) {
+	x := missing.List[int]{1, 2, 3, 2, 4, 2}
+	if v, err := x.RemoveAt(0); v != 1 || err != nil || !x.Equal(missing.List[int]{2, 3, 2, 4, 2}) {
+		t.Errorf("RemoveAt is wrong: %v %d %v", x, v, err)
+	}
+	if _, err := x.RemoveAt(5); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("RemoveAt past the end didn't return ErrOutOfRange: %v", err)
+	}
+	if !x.RemoveValue(2) || !x.Equal(missing.List[int]{3, 2, 4, 2}) {
+		t.Errorf("RemoveValue is wrong: %v", x)
+	}
+	if x.RemoveValue(99) {
+		t.Error("RemoveValue removed something that wasn't there")
+	}
+	if n := x.RemoveFunc(func(v int) bool { return v == 2 }); n != 2 || !x.Equal(missing.List[int]{3, 4}) {
+		t.Errorf("RemoveFunc is wrong: %v removed %d", x, n)
+	}
+}
+
+func TestListPopShift(t *testing.T) {
+	x := missing.List[int]{1, 2, 3}
+	if v, ok := x.Pop(); v != 3 || !ok || !x.Equal(missing.List[int]{1, 2}) {
+		t.Errorf("Pop is wrong: %v %d", x, v)
+	}
+	if v, ok := x.Shift(); v != 1 || !ok || !x.Equal(missing.List[int]{2}) {
+		t.Errorf("Shift is wrong: %v %d", x, v)
+	}
+	x.Pop()
+	if _, ok := x.Pop(); ok {
+		t.Error("Pop of an empty list returned something")
+	}
+	if _, ok := x.Shift(); ok {
+		t.Error("Shift of an empty list returned something")
+	}
+}
+
+func TestListIndexOf(t *testing.T) {
+	x := missing.List[string]{"a", "b", "a", "c"}
+	if x.IndexOf("a") != 0 || x.LastIndexOf("a") != 2 || x.IndexOf("z") != -1 || x.LastIndexOf("z") != -1 {
+		t.Errorf("IndexOf/LastIndexOf are wrong")
+	}
+}
+
+func TestListReverseClone(t *testing.T) {
+	x := missing.List[int]{1, 2, 3, 4}
+	y := x.Clone()
+	x.Reverse()
+	if !x.Equal(missing.List[int]{4, 3, 2, 1}) {
+		t.Errorf("Reverse is wrong: %v", x)
+	}
+	if !y.Equal(missing.List[int]{1, 2, 3, 4}) {
+		t.Errorf("Clone shares memory with the original: %v", y)
+	}
+	if x.Equal(y) || x.Equal(missing.List[int]{4, 3, 2}) {
+		t.Error("Equal is wrong")
+	}
+}
+
+func ExampleList_Sort() {
+	list := missing.List[string]{"pear", "fig", "banana"}
+	list.Sort(func(a, b string) bool { return len(a) < len(b) })
+	fmt.Println(list)
+	// Output: [fig pear banana]
+}
+
+func TestListSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	x := missing.List[int]{5, 2, 8, 1, 9}
+	if x.IsSorted(less) {
+		t.Error("IsSorted said an unsorted list was sorted")
+	}
+	x.Sort(less)
+	if !x.Equal(missing.List[int]{1, 2, 5, 8, 9}) || !x.IsSorted(less) {
+		t.Errorf("Sort is wrong: %v", x)
+	}
+	if i, found := x.BinarySearch(8, less); i != 3 || !found {
+		t.Errorf("BinarySearch(8) is wrong: %d %t", i, found)
+	}
+	if i, found := x.BinarySearch(6, less); i != 3 || found {
+		t.Errorf("BinarySearch(6) is wrong: %d %t", i, found)
+	}
+	if i, found := x.BinarySearch(10, less); i != 5 || found {
+		t.Errorf("BinarySearch(10) is wrong: %d %t", i, found)
+	}
+}
+
+func TestListBinarySearchDuplicateKeys(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	byAge := func(a, b person) bool { return a.age < b.age }
+	x := missing.List[person]{{"A", 30}, {"B", 30}, {"C", 40}}
+	if i, found := x.BinarySearch(person{"B", 30}, byAge); i != 0 || !found {
+		t.Errorf("BinarySearch didn't find an item with a duplicate key: %d %t", i, found)
+	}
+	if i, found := x.BinarySearch(person{"D", 40}, byAge); i != 2 || !found {
+		t.Errorf("BinarySearch didn't match on the key: %d %t", i, found)
+	}
+	if i, found := x.BinarySearch(person{"E", 35}, byAge); i != 2 || found {
+		t.Errorf("BinarySearch found a missing key: %d %t", i, found)
+	}
+	// AnyList gives the same answers for the same data
+	y := missing.AnyList[person](x)
+	if i, found := y.BinarySearch(person{"B", 30}, byAge); i != 0 || !found {
+		t.Errorf("AnyList BinarySearch differs from List: %d %t", i, found)
+	}
+}
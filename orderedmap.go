@@ -0,0 +1,313 @@
+package missing
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// An OrderedMap is a map that remembers the order keys were added in. Keys, Values, Entries, String and MarshalJSON
+// all return entries in that order, so unlike a map the output is the same every time. Get, Set and Delete are all
+// O(1). Setting a key that is already in the map changes it's value, but not it's position.
+//
+// It marshalls to a json object with the keys in order, and unmarshalls keeping the order of the keys in the
+// document, so config files and API responses round trip unchanged. As with maps, keys must be strings, integers
+// or implement encoding.TextMarshaler (and TextUnmarshaler) to be used with json.
+//
+// The zero value is an empty map ready to use. Methods that change the map need a pointer, but the rest (including
+// String and MarshalJSON) work on a copy, so a map held by value in a struct still prints and json encodes correctly.
+//
+//   m := missing.NewOrderedMap[string, int]()
+//   m.Set("b", 2)
+//   m.Set("a", 1)
+//   fmt.Println(m) // map[b:2 a:1]
+type OrderedMap[K comparable, V any] struct {
+	index map[K]*orderedMapNode[K, V]
+	root  *orderedMapNode[K, V] // root.next is the first entry, root.prev the last. Created along with index.
+}
+
+type orderedMapNode[K comparable, V any] struct {
+	key        K
+	val        V
+	prev, next *orderedMapNode[K, V]
+}
+
+// Creates a new empty ordered map.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// Returns the number of entries in the map.
+func (m OrderedMap[K, V]) Length() int {
+	return len(m.index)
+}
+
+// Returns the value for the key, or false if the key isn't in the map.
+func (m OrderedMap[K, V]) Get(key K) (V, bool) {
+	n, found := m.index[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return n.val, true
+}
+
+// Returns true if the key is in the map.
+func (m OrderedMap[K, V]) Contains(key K) bool {
+	_, found := m.index[key]
+	return found
+}
+
+// Sets the value for the key. New keys are added to the end, existing keys keep their position.
+func (m *OrderedMap[K, V]) Set(key K, val V) {
+	if n, found := m.index[key]; found {
+		n.val = val
+		return
+	}
+	if m.index == nil {
+		m.index = make(map[K]*orderedMapNode[K, V])
+		m.root = &orderedMapNode[K, V]{}
+		m.root.next = m.root
+		m.root.prev = m.root
+	}
+	n := &orderedMapNode[K, V]{key: key, val: val}
+	m.link(n, m.root.prev)
+	m.index[key] = n
+}
+
+// Removes the key from the map. Returns false if it wasn't there.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	n, found := m.index[key]
+	if !found {
+		return false
+	}
+	m.unlink(n)
+	delete(m.index, key)
+	return true
+}
+
+// Moves the key to the start of the map. Returns false if it isn't in the map.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	n, found := m.index[key]
+	if !found {
+		return false
+	}
+	m.unlink(n)
+	m.link(n, m.root)
+	return true
+}
+
+// Moves the key to the end of the map. Returns false if it isn't in the map.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	n, found := m.index[key]
+	if !found {
+		return false
+	}
+	m.unlink(n)
+	m.link(n, m.root.prev)
+	return true
+}
+
+// Returns the keys of the map, in order.
+func (m OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.index))
+	m.Foreach(func(k K, v V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Returns the values of the map, in order.
+func (m OrderedMap[K, V]) Values() []V {
+	vals := make([]V, 0, len(m.index))
+	m.Foreach(func(k K, v V) {
+		vals = append(vals, v)
+	})
+	return vals
+}
+
+// Returns the entries of the map as key (V1) and value (V2) pairs, in order.
+func (m OrderedMap[K, V]) Entries() []Pair[K, V] {
+	entries := make([]Pair[K, V], 0, len(m.index))
+	m.Foreach(func(k K, v V) {
+		entries = append(entries, Pair[K, V]{k, v})
+	})
+	return entries
+}
+
+// Calls the provided function for each key and value in the map, in order. Do not modify the map inside the
+// callback.
+func (m OrderedMap[K, V]) Foreach(fn func(K, V)) {
+	if m.index == nil {
+		return
+	}
+	for n := m.root.next; n != m.root; n = n.next {
+		fn(n.key, n.val)
+	}
+}
+
+// Returns a Seq2 of the keys and values in the map, in order. (See Seq2)
+func (m OrderedMap[K, V]) All() Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.index == nil {
+			return
+		}
+		for n := m.root.next; n != m.root; n = n.next {
+			if !yield(n.key, n.val) {
+				return
+			}
+		}
+	}
+}
+
+// A string representation of the map, in the same format as fmt uses for maps, but in order.
+func (m OrderedMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("map[")
+	first := true
+	m.Foreach(func(k K, v V) {
+		if !first {
+			sb.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&sb, "%v:%v", k, v)
+	})
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// An ordered map marshalls into a json object, with the keys in order.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range m.Entries() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := marshalMapKey(e.V1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteByte(':')
+		b, err = json.Marshal(e.V2)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// An ordered map unmarshalls from a json object, keeping the order of the keys in the object. If a key appears more
+// than once, the last value wins but the key keeps it's first position. The map's existing entries are replaced,
+// but only once the whole object has decoded, so on an error the map is left unchanged. A json null empties it.
+func (m *OrderedMap[K, V]) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		*m = OrderedMap[K, V]{} // null
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("missing: cannot unmarshal %v into an OrderedMap", tok)
+	}
+	var decoded OrderedMap[K, V]
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var key K
+		if err := unmarshalMapKey(tok.(string), &key); err != nil {
+			return err
+		}
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		decoded.Set(key, val)
+	}
+	if _, err := dec.Token(); err != nil { // The closing '}'
+		return err
+	}
+	*m = decoded
+	return nil
+}
+
+// Inserts n into the list after the node at.
+func (m *OrderedMap[K, V]) link(n, at *orderedMapNode[K, V]) {
+	n.prev = at
+	n.next = at.next
+	at.next.prev = n
+	at.next = n
+}
+
+func (m *OrderedMap[K, V]) unlink(n *orderedMapNode[K, V]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+}
+
+// Converts a map key into the string used as it's json object key, following the same rules as encoding/json:
+// string kinds are used as is (even if they implement encoding.TextMarshaler), then TextMarshalers, then integers.
+func marshalMapKey(key any) (string, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if tm, ok := key.(encoding.TextMarshaler); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("missing: unsupported json key type %T", key)
+}
+
+// Converts a json object key into a map key, the reverse of marshalMapKey. As with encoding/json, an
+// encoding.TextUnmarshaler is used first, even for string kinds.
+func unmarshalMapKey(s string, key any) error {
+	if tu, ok := key.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+	v := reflect.ValueOf(key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("missing: invalid json key %q for %v: %w", s, v.Type(), err)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("missing: invalid json key %q for %v: %w", s, v.Type(), err)
+		}
+		v.SetUint(n)
+		return nil
+	}
+	return fmt.Errorf("missing: unsupported json key type %v", v.Type())
+}
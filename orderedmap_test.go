@@ -0,0 +1,147 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleOrderedMap() {
+	m := missing.NewOrderedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	m.Set("c", 3)
+	m.MoveToFront("c")
+	bytes, _ := json.Marshal(m)
+	fmt.Println(m, string(bytes))
+	// Output: map[c:3 b:2 a:1] {"c":3,"b":2,"a":1}
+}
+
+func TestOrderedMap(t *testing.T) {
+	var m missing.OrderedMap[string, int]
+	if m.Length() != 0 || len(m.Keys()) != 0 || m.String() != "map[]" {
+		t.Error("Zero value OrderedMap isn't empty")
+	}
+	for i, k := range []string{"z", "y", "x", "w"} {
+		m.Set(k, i)
+	}
+	m.Set("y", 10) // Keeps it's position
+	if fmt.Sprint(m.Keys()) != "[z y x w]" || fmt.Sprint(m.Values()) != "[0 10 2 3]" {
+		t.Errorf("Set is wrong: %v", m.String())
+	}
+	if v, ok := m.Get("y"); v != 10 || !ok {
+		t.Errorf("Get is wrong: %d %v", v, ok)
+	}
+	if _, ok := m.Get("a"); ok || m.Contains("a") {
+		t.Error("Get of a missing key returned ok")
+	}
+	if !m.Delete("x") || m.Delete("x") || m.Length() != 3 {
+		t.Error("Delete is wrong")
+	}
+	if !m.MoveToBack("z") || !m.MoveToFront("w") || m.MoveToFront("x") {
+		t.Error("Move returned the wrong result")
+	}
+	if m.String() != "map[w:3 y:10 z:0]" {
+		t.Errorf("Move is wrong: %v", m.String())
+	}
+	entries := m.Entries()
+	if len(entries) != 3 || entries[0].V1 != "w" || entries[0].V2 != 3 {
+		t.Errorf("Entries is wrong: %v", entries)
+	}
+	var keys []string
+	m.All()(func(k string, v int) bool {
+		keys = append(keys, k)
+		return len(keys) < 2
+	})
+	if fmt.Sprint(keys) != "[w y]" {
+		t.Errorf("All is wrong: %v", keys)
+	}
+}
+
+func TestOrderedMapJson(t *testing.T) {
+	doc := `{"zebra":{"legs":4},"ant":{"legs":6},"bird":{"legs":2},"ant":{"legs":5}}`
+	var m missing.OrderedMap[string, map[string]int]
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(m.Keys()) != "[zebra ant bird]" {
+		t.Errorf("Unmarshal didn't keep the key order: %v", m.Keys())
+	}
+	if v, _ := m.Get("ant"); v["legs"] != 5 {
+		t.Errorf("Duplicate key didn't take the last value: %v", v)
+	}
+	bytes, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"zebra":{"legs":4},"ant":{"legs":5},"bird":{"legs":2}}` {
+		t.Errorf("Marshal didn't keep the key order: %s", bytes)
+	}
+
+	// Integer keys are quoted, as they are for maps
+	var ints missing.OrderedMap[int, string]
+	ints.Set(3, "c")
+	ints.Set(-1, "a")
+	bytes, _ = json.Marshal(&ints)
+	if string(bytes) != `{"3":"c","-1":"a"}` {
+		t.Errorf("Integer keys marshalled wrong: %s", bytes)
+	}
+	var back missing.OrderedMap[int, string]
+	if err := json.Unmarshal(bytes, &back); err != nil || back.String() != "map[3:c -1:a]" {
+		t.Errorf("Integer keys unmarshalled wrong: %v %v", back.String(), err)
+	}
+	if err := json.Unmarshal([]byte(`{"x":"a"}`), &back); err == nil {
+		t.Error("Invalid integer key didn't error")
+	}
+	if err := json.Unmarshal([]byte(`[1,2]`), &back); err == nil {
+		t.Error("Unmarshalling an array didn't error")
+	}
+	if err := json.Unmarshal([]byte(`{"1":"x","2":5}`), &back); err == nil {
+		t.Error("Unmarshalling a bad value didn't error")
+	}
+	if back.String() != "map[3:c -1:a]" {
+		t.Errorf("Failed unmarshal changed the map: %v", back.String())
+	}
+	if err := json.Unmarshal([]byte(`null`), &back); err != nil || back.Length() != 0 {
+		t.Errorf("Unmarshalling null didn't empty the map: %v %v", back.String(), err)
+	}
+
+	// Held by value in a struct
+	held := struct {
+		M missing.OrderedMap[int, string]
+		E missing.OrderedMap[string, int]
+	}{M: ints}
+	bytes, err = json.Marshal(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"M":{"3":"c","-1":"a"},"E":{}}` {
+		t.Errorf("OrderedMap held by value didn't marshal: %s", bytes)
+	}
+	if fmt.Sprint(held.E) != "map[]" {
+		t.Errorf("Zero value OrderedMap held by value didn't print: %v", held.E)
+	}
+}
+
+// A string key type that also has a MarshalText method. encoding/json documents that map keys of any string type are
+// used directly, so MarshalText is ignored.
+type upperKey string
+
+func (k upperKey) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(k))), nil
+}
+
+func TestOrderedMapJsonKeyRules(t *testing.T) {
+	var m missing.OrderedMap[upperKey, int]
+	m.Set("a", 1)
+	got, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `{"a":1}` {
+		t.Errorf("String kind key wasn't used directly: %s", got)
+	}
+}
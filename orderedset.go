@@ -0,0 +1,184 @@
+package missing
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// An OrderedSet is a set that remembers the order items were added in. ToSlice, String and MarshalJSON all return
+// items in that order, so unlike a Set the output is the same every time. Contains, Add and Remove are all O(1).
+// Adding an item that is already in the set doesn't change it's position.
+//
+// Underneath it is a map into a doubly linked list. The zero value is an empty set ready to use. Methods that add
+// or remove items need a pointer, but the rest (including String and MarshalJSON) work on a copy, so a set held by
+// value in a struct still prints and json encodes correctly.
+//
+//   s := missing.NewOrderedSet([]string{"b", "a", "c"})
+//   s.Add("a", "d")
+//   fmt.Println(s) // [b a c d]
+type OrderedSet[T comparable] struct {
+	index map[T]*orderedSetNode[T]
+	root  *orderedSetNode[T] // root.next is the first item, root.prev the last. Created along with index.
+}
+
+type orderedSetNode[T comparable] struct {
+	val        T
+	prev, next *orderedSetNode[T]
+}
+
+// Creates a new ordered set, using the provided slice. Duplicates keep their first position.
+func NewOrderedSet[T comparable](slice []T) *OrderedSet[T] {
+	s := &OrderedSet[T]{}
+	s.Add(slice...)
+	return s
+}
+
+// Creates a copy of the set as a slice, in the order the items were added.
+func (s OrderedSet[T]) ToSlice() []T {
+	list := make([]T, 0, len(s.index))
+	s.Foreach(func(v T) {
+		list = append(list, v)
+	})
+	return list
+}
+
+// Creates a copy of the set as an (unordered) Set.
+func (s OrderedSet[T]) ToSet() Set[T] {
+	set := make(Set[T], len(s.index))
+	for k := range s.index {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// Calls the provided function for each item in the set, in order. Do not modify the set inside the callback.
+func (s OrderedSet[T]) Foreach(fn func(T)) {
+	if s.index == nil {
+		return
+	}
+	for n := s.root.next; n != s.root; n = n.next {
+		fn(n.val)
+	}
+}
+
+// Returns a Seq of the items in the set, in order. (See Seq)
+func (s OrderedSet[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		if s.index == nil {
+			return
+		}
+		for n := s.root.next; n != s.root; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Returns true if the set contains the provided value.
+func (s OrderedSet[T]) Contains(v T) bool {
+	_, found := s.index[v]
+	return found
+}
+
+// Returns the length of the set.
+func (s OrderedSet[T]) Length() int {
+	return len(s.index)
+}
+
+// Adds individual value(s) to the end of the set. Values already in the set keep their position.
+func (s *OrderedSet[T]) Add(vals ...T) {
+	if s.index == nil {
+		s.index = make(map[T]*orderedSetNode[T], len(vals))
+		s.root = &orderedSetNode[T]{}
+		s.root.next = s.root
+		s.root.prev = s.root
+	}
+	for _, v := range vals {
+		if _, found := s.index[v]; found {
+			continue
+		}
+		n := &orderedSetNode[T]{val: v, prev: s.root.prev, next: s.root}
+		n.prev.next = n
+		s.root.prev = n
+		s.index[v] = n
+	}
+}
+
+// Adds the records from the slice to this set inplace. Functionaly the same as s.Add(slice...)
+func (s *OrderedSet[T]) AddSlice(slice []T) {
+	s.Add(slice...)
+}
+
+// Adds the values from the supplied set to the end of this set, in b's order. (inplace operation, c.f. Union)
+func (a *OrderedSet[T]) AddSet(b *OrderedSet[T]) {
+	b.Foreach(func(v T) {
+		a.Add(v)
+	})
+}
+
+// Removes value(s) from the set. Values that aren't in the set are ignored.
+func (s *OrderedSet[T]) Remove(vals ...T) {
+	for _, v := range vals {
+		n, found := s.index[v]
+		if !found {
+			continue
+		}
+		n.prev.next = n.next
+		n.next.prev = n.prev
+		delete(s.index, v)
+	}
+}
+
+// Returns the difference of this set and the provided set (a - b), in a's order.
+func (a OrderedSet[T]) Difference(b *OrderedSet[T]) *OrderedSet[T] {
+	diff := &OrderedSet[T]{}
+	a.Foreach(func(v T) {
+		if !b.Contains(v) {
+			diff.Add(v)
+		}
+	})
+	return diff
+}
+
+// Returns the union of set a + b, with a's items first (in a's order) followed by the items only in b.
+func (a OrderedSet[T]) Union(b *OrderedSet[T]) *OrderedSet[T] {
+	union := &OrderedSet[T]{}
+	union.AddSet(&a)
+	union.AddSet(b)
+	return union
+}
+
+// Returns the intersection of sets a and b, in a's order.
+func (a OrderedSet[T]) Intersection(b *OrderedSet[T]) *OrderedSet[T] {
+	intersection := &OrderedSet[T]{}
+	a.Foreach(func(v T) {
+		if b.Contains(v) {
+			intersection.Add(v)
+		}
+	})
+	return intersection
+}
+
+// A string representation of the set (a string formated list, in order)
+func (s OrderedSet[T]) String() string {
+	return fmt.Sprint(s.ToSlice())
+}
+
+// An ordered set marshalls into a json array, in order.
+func (s OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// An ordered set unmarshalls from a json array, keeping the order of the array (duplicates keep their first
+// position).
+func (s *OrderedSet[T]) UnmarshalJSON(b []byte) error {
+	var list []T
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	*s = OrderedSet[T]{}
+	s.Add(list...)
+	return nil
+}
@@ -0,0 +1,92 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleOrderedSet() {
+	s := missing.NewOrderedSet([]string{"b", "a", "c"})
+	s.Add("a", "d")
+	fmt.Println(s)
+	// Output: [b a c d]
+}
+
+func TestOrderedSet(t *testing.T) {
+	var s missing.OrderedSet[int]
+	if s.Length() != 0 || s.Contains(1) || len(s.ToSlice()) != 0 {
+		t.Error("Zero value OrderedSet isn't empty")
+	}
+	s.Add(5, 3, 1, 3)
+	s.AddSlice([]int{4, 5, 2})
+	if fmt.Sprint(s.ToSlice()) != "[5 3 1 4 2]" {
+		t.Errorf("OrderedSet didn't keep insertion order: %v", s.ToSlice())
+	}
+	s.Remove(3, 99)
+	s.Remove(2)
+	if fmt.Sprint(s.ToSlice()) != "[5 1 4]" || s.Length() != 3 || s.Contains(3) {
+		t.Errorf("OrderedSet didn't remove values: %v", s.ToSlice())
+	}
+	s.Add(3)
+	if fmt.Sprint(s.ToSlice()) != "[5 1 4 3]" {
+		t.Errorf("Re-added value didn't go to the end: %v", s.ToSlice())
+	}
+	s.Remove(5, 1, 4, 3)
+	s.Add(7)
+	if fmt.Sprint(s.ToSlice()) != "[7]" {
+		t.Errorf("OrderedSet is wrong after emptying: %v", s.ToSlice())
+	}
+}
+
+func TestOrderedSetAlgebra(t *testing.T) {
+	a := missing.NewOrderedSet([]int{5, 4, 3, 2, 1})
+	b := missing.NewOrderedSet([]int{9, 2, 4, 8})
+
+	if u := a.Union(b).String(); u != "[5 4 3 2 1 9 8]" {
+		t.Errorf("Union is wrong: %s", u)
+	}
+	if i := a.Intersection(b).String(); i != "[4 2]" {
+		t.Errorf("Intersection is wrong: %s", i)
+	}
+	if d := a.Difference(b).String(); d != "[5 3 1]" {
+		t.Errorf("Difference is wrong: %s", d)
+	}
+	a.AddSet(b)
+	if a.String() != "[5 4 3 2 1 9 8]" {
+		t.Errorf("AddSet is wrong: %s", a)
+	}
+	if set := a.ToSet(); len(set) != 7 || !set.Contains(9) {
+		t.Errorf("ToSet is wrong: %v", set)
+	}
+}
+
+func TestOrderedSetJson(t *testing.T) {
+	a := missing.NewOrderedSet([]string{"zebra", "apple", "mango"})
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `["zebra","apple","mango"]` {
+		t.Errorf("OrderedSet didn't marshal in order: %s", bytes)
+	}
+	var b missing.OrderedSet[string]
+	if err := json.Unmarshal([]byte(`["x","y","x","z"]`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "[x y z]" {
+		t.Errorf("OrderedSet didn't unmarshal in order: %s", b.String())
+	}
+
+	// Held by value in a struct
+	held := struct{ S missing.OrderedSet[string] }{b}
+	bytes, err = json.Marshal(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"S":["x","y","z"]}` {
+		t.Errorf("OrderedSet held by value didn't marshal: %s", bytes)
+	}
+}
@@ -0,0 +1,61 @@
+package missing
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// A PanicError is returned in place of a function's result when the function panicked. This is used by the
+// Timeout functions and promises, where the function runs on a goroutine the caller can't recover from,
+// so without it a single panic would take down the whole process.
+//
+//   _, err := missing.TimeoutFnErr(time.Second, mightPanic)
+//   var perr *missing.PanicError
+//   if errors.As(err, &perr) {
+//   	log.Printf("mightPanic panicked: %v\n%s", perr.Value, perr.Stack)
+//   }
+type PanicError struct {
+	Value any    // The value that was passed to panic()
+	Stack []byte // The stack trace of the goroutine at the time of the panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// If the function panicked with an error, returns that error, so errors.Is and errors.As can see through
+// the PanicError to it.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Calls the supplied function and returns it's values. If the function panics, the panic is recovered and
+// returned as a *PanicError instead (unless recovering panics has been turned off with RecoverPanics).
+func CatchPanic[T any](fn func() (T, error)) (val T, err error) {
+	if atomic.LoadInt32(&crashOnPanic) != 0 {
+		return fn()
+	}
+	// recover() returns nil for panic(nil), so track whether fn returned rather than trusting it's value
+	panicked := true
+	defer func() {
+		r := recover()
+		if panicked {
+			var zero T
+			val, err = zero, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	val, err = fn()
+	panicked = false
+	return val, err
+}
+
+// Turns recovering panics on (the default) or off. When off, a panic in a function run by the Timeout
+// functions or a promise is left alone, and will crash the program as it normally would. For those that
+// prefer to fail loudly.
+func RecoverPanics(enabled bool) {
+	atomic.StoreInt32(&crashOnPanic, If[int32](enabled, 0, 1))
+}
+
+var crashOnPanic int32
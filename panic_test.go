@@ -0,0 +1,60 @@
+package missing_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestCatchPanic(t *testing.T) {
+	val, err := missing.CatchPanic(func() (int, error) {
+		return 42, nil
+	})
+	if val != 42 || err != nil {
+		t.Errorf("CatchPanic changed a normal return: %d %v", val, err)
+	}
+
+	_, err = missing.CatchPanic(func() (int, error) {
+		panic("blerg")
+	})
+	var perr *missing.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Panic was not returned as a PanicError: %v", err)
+	}
+	if perr.Value != "blerg" {
+		t.Errorf("PanicError has the wrong value: %v", perr.Value)
+	}
+	if !strings.Contains(string(perr.Stack), "panic_test.go") {
+		t.Errorf("PanicError stack doesn't include the panicking function:\n%s", perr.Stack)
+	}
+
+	blerg := errors.New("blerg")
+	_, err = missing.CatchPanic(func() (int, error) {
+		panic(blerg)
+	})
+	if !errors.Is(err, blerg) {
+		t.Errorf("PanicError doesn't unwrap to the error passed to panic: %v", err)
+	}
+
+	_, err = missing.CatchPanic(func() (int, error) {
+		panic(nil)
+	})
+	if !errors.As(err, &perr) {
+		t.Errorf("panic(nil) was not returned as a PanicError: %v", err)
+	}
+}
+
+func TestTimeoutFnPanic(t *testing.T) {
+	_, err := missing.TimeoutFn(time.Second, func() int {
+		var m map[string]int
+		m["oops"] = 1
+		return 0
+	})
+	var perr *missing.PanicError
+	if !errors.As(err, &perr) {
+		t.Errorf("TimeoutFn didn't return a PanicError: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package missing
+
+// A PriorityQueue holds values ordered by a less function, so that Pop always returns the smallest value (the one
+// that is less than all the others). It is a binary heap, so Push, Pop, Update and Remove are all O(log n), and Peek
+// is O(1). Unlike container/heap there are no interfaces to implement, just a less function.
+//
+// Push returns a handle to the value, which can later be used to change the value's priority (Update, Fix), or to
+// remove it from the middle of the queue (Remove).
+//
+// Create it with NewPriorityQueue (or NewMinQueue and NewMaxQueue). The zero value is an empty queue with no
+// ordering, so pushing to it panics.
+//
+//   type Job struct { Name string; Priority int }
+//   jobs := missing.NewPriorityQueue(func(a, b Job) bool { return a.Priority > b.Priority })
+//   jobs.Push(Job{"backup", 1})
+//   urgent := jobs.Push(Job{"report", 5})
+//   jobs.Update(urgent, Job{"report", 0}) // Not so urgent after all
+//   job, _ := jobs.Pop() // {backup 1}
+type PriorityQueue[T any] struct {
+	items []*PQHandle[T]
+	less  func(a, b T) bool
+}
+
+// A handle to a value in a PriorityQueue, returned by Push. The handle stays valid until the value is popped or
+// removed from the queue.
+type PQHandle[T any] struct {
+	Value T
+	index int // Position in the queue's heap, or -1 once it's no longer in the queue
+}
+
+// Creates an empty priority queue, where Pop returns the value that is less than all the others according to the
+// less function.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Creates an empty priority queue of numbers or strings, where Pop returns the smallest value.
+func NewMinQueue[T Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return a < b })
+}
+
+// Creates an empty priority queue of numbers or strings, where Pop returns the largest value.
+func NewMaxQueue[T Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return a > b })
+}
+
+// Returns the number of values in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Adds a value to the queue, and returns a handle to it. Panics if the queue has no less function (it wasn't
+// created with NewPriorityQueue, NewMinQueue or NewMaxQueue).
+func (pq *PriorityQueue[T]) Push(val T) *PQHandle[T] {
+	if pq.less == nil {
+		panic("missing: PriorityQueue has no less function, create it with NewPriorityQueue, NewMinQueue or NewMaxQueue")
+	}
+	h := &PQHandle[T]{Value: val, index: len(pq.items)}
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	return h
+}
+
+// Adds all the values in the slice to the queue.
+func (pq *PriorityQueue[T]) PushSlice(vals []T) {
+	for _, v := range vals {
+		pq.Push(v)
+	}
+}
+
+// Removes the smallest value from the queue and returns it, or returns false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(0), true
+}
+
+// Returns the smallest value in the queue without removing it, or false if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0].Value, true
+}
+
+// Changes the value of the handle and moves it to it's new place in the queue. Returns false if the handle is no
+// longer in the queue.
+func (pq *PriorityQueue[T]) Update(h *PQHandle[T], val T) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	h.Value = val
+	pq.fix(h.index)
+	return true
+}
+
+// Moves the handle to it's new place in the queue, after it's Value has been modified inplace (eg if it's a pointer
+// or struct whose priority field was changed). Returns false if the handle is no longer in the queue.
+func (pq *PriorityQueue[T]) Fix(h *PQHandle[T]) bool {
+	if !pq.owns(h) {
+		return false
+	}
+	pq.fix(h.index)
+	return true
+}
+
+// Removes the handle's value from the queue, wherever it is, and returns it. Returns false if the handle is no
+// longer in the queue.
+func (pq *PriorityQueue[T]) Remove(h *PQHandle[T]) (T, bool) {
+	if !pq.owns(h) {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(h.index), true
+}
+
+// Removes every value from the queue. Any outstanding handles become invalid.
+func (pq *PriorityQueue[T]) Clear() {
+	for _, h := range pq.items {
+		h.index = -1
+	}
+	pq.items = nil
+}
+
+// Returns a Seq of the values in the queue, in no particular order. (See Seq)
+func (pq *PriorityQueue[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		for _, h := range pq.items {
+			if !yield(h.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns true if the handle is currently in this queue.
+func (pq *PriorityQueue[T]) owns(h *PQHandle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+// Removes the item at index i of the heap, and restores the heap order.
+func (pq *PriorityQueue[T]) removeAt(i int) T {
+	h := pq.items[i]
+	last := len(pq.items) - 1
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	if i != last {
+		pq.fix(i)
+	}
+	h.index = -1
+	return h.Value
+}
+
+// Moves the item at index i up or down the heap, to wherever it belongs.
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+// Moves the item at index i up the heap while it's less than it's parent.
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].Value, pq.items[parent].Value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// Moves the item at index i down the heap while either child is less than it. Returns true if it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < n && pq.less(pq.items[child].Value, pq.items[smallest].Value) {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return i != start
+		}
+		pq.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
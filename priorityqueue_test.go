@@ -0,0 +1,121 @@
+package missing_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExamplePriorityQueue() {
+	type Job struct {
+		Name     string
+		Priority int
+	}
+	jobs := missing.NewPriorityQueue(func(a, b Job) bool { return a.Priority > b.Priority })
+	jobs.Push(Job{"backup", 1})
+	urgent := jobs.Push(Job{"report", 5})
+	jobs.Update(urgent, Job{"report", 0})
+	job, _ := jobs.Pop()
+	fmt.Println(job.Name)
+	// Output: backup
+}
+
+func TestPriorityQueue(t *testing.T) {
+	pq := missing.NewMinQueue[int]()
+	if _, ok := pq.Pop(); ok {
+		t.Error("Pop of an empty queue returned ok")
+	}
+	vals := rand.Perm(200)
+	pq.PushSlice(vals)
+	if v, _ := pq.Peek(); v != 0 || pq.Len() != 200 {
+		t.Errorf("Peek is wrong: %d", v)
+	}
+	for i := 0; i < 200; i++ {
+		if v, ok := pq.Pop(); v != i || !ok {
+			t.Fatalf("Pop %d is wrong: %d", i, v)
+		}
+	}
+	if pq.Len() != 0 {
+		t.Errorf("Queue isn't empty: %d", pq.Len())
+	}
+}
+
+func TestMaxQueue(t *testing.T) {
+	pq := missing.NewMaxQueue[string]()
+	pq.PushSlice([]string{"b", "d", "a", "c"})
+	var got []string
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[d c b a]" {
+		t.Errorf("MaxQueue order is wrong: %v", got)
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	pq := missing.NewMinQueue[int]()
+	handles := map[int]*missing.PQHandle[int]{}
+	for i := 0; i < 50; i++ {
+		handles[i] = pq.Push(i * 10)
+	}
+	// Make 45 the smallest, remove some from the middle
+	if !pq.Update(handles[45], -1) {
+		t.Error("Update returned false")
+	}
+	for _, i := range []int{0, 10, 20, 30, 49} {
+		if v, ok := pq.Remove(handles[i]); v != i*10 || !ok {
+			t.Errorf("Remove(%d) is wrong: %d", i, v)
+		}
+	}
+	if _, ok := pq.Remove(handles[10]); ok {
+		t.Error("Removing twice returned ok")
+	}
+	if pq.Update(handles[0], 5) || pq.Fix(handles[0]) {
+		t.Error("Update or Fix of a removed handle returned true")
+	}
+	handles[1].Value = 1000
+	pq.Fix(handles[1])
+
+	var want []int
+	for i := 0; i < 50; i++ {
+		switch i {
+		case 0, 10, 20, 30, 49:
+		case 45:
+			want = append(want, -1)
+		case 1:
+			want = append(want, 1000)
+		default:
+			want = append(want, i*10)
+		}
+	}
+	sort.Ints(want)
+	var got []int
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Queue order is wrong:\n%v\n%v", got, want)
+	}
+	if _, ok := pq.Remove(handles[2]); ok {
+		t.Error("Removing a popped handle returned ok")
+	}
+}
+
+func TestPriorityQueueZeroValue(t *testing.T) {
+	var pq missing.PriorityQueue[int]
+	if _, ok := pq.Pop(); ok || pq.Len() != 0 {
+		t.Error("Zero value PriorityQueue isn't empty")
+	}
+	defer func() {
+		if msg, _ := recover().(string); !strings.Contains(msg, "NewPriorityQueue") {
+			t.Errorf("Push on a zero value PriorityQueue didn't panic clearly: %v", msg)
+		}
+	}()
+	pq.Push(1)
+}
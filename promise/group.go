@@ -0,0 +1,70 @@
+package promise
+
+import (
+	"sync"
+	"time"
+)
+
+// A Group deduplicates calls for the same key. While a promise made by Do for a key is still running, every other
+// Do with that key returns the same promise rather than calling it's function again, so a hundred goroutines
+// asking for the same expensive resource only fetch it once. (Like golang.org/x/sync/singleflight, but with
+// promises.)
+//
+// If TTL is set, a promise that resolved successfully is kept and returned by Do for that long afterwards,
+// caching it's result. Rejected promises are never kept. The zero value is a Group ready to use, without caching.
+//
+// Because the promise is shared, cancelling it cancels it for every caller.
+//
+//    var users promise.Group[int, *User]
+//    p := users.Do(id, func() (*User, error) {
+//        return db.LoadUser(id)
+//    })
+//    user, err := p.Await()
+type Group[K comparable, T any] struct {
+	TTL   time.Duration // How long to keep a resolved promise after it finishes. 0 only shares running promises.
+	mu    sync.Mutex
+	calls map[K]*Promise[T]
+}
+
+// Returns the promise for the key if there is one running (or cached, see TTL), otherwise calls fn in a new
+// promise, as New does, and returns that.
+func (g *Group[K, T]) Do(key K, fn func() (T, error)) *Promise[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p, ok := g.calls[key]; ok {
+		return p
+	}
+	if g.calls == nil {
+		g.calls = make(map[K]*Promise[T])
+	}
+	p := New(fn)
+	g.calls[key] = p
+	go func() {
+		_, err := p.Await()
+		if err != nil || g.TTL <= 0 {
+			g.forget(key, p)
+			return
+		}
+		time.AfterFunc(g.TTL, func() {
+			g.forget(key, p)
+		})
+	}()
+	return p
+}
+
+// Forgets the promise for the key, whether it's still running or cached, so the next Do for it calls it's function
+// again. Callers that already have the promise are unaffected.
+func (g *Group[K, T]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
+// Removes the promise for the key, but only if it hasn't already been replaced by a newer one.
+func (g *Group[K, T]) forget(key K, p *Promise[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == p {
+		delete(g.calls, key)
+	}
+}
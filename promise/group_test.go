@@ -0,0 +1,89 @@
+package promise_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing/promise"
+)
+
+func TestGroup(t *testing.T) {
+	var g promise.Group[string, int]
+	var calls int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+	p1 := g.Do("a", fn)
+	p2 := g.Do("a", fn)
+	p3 := g.Do("b", fn)
+	if p1 != p2 || p1 == p3 {
+		t.Error("Do didn't share the promise for the same key")
+	}
+	close(release)
+	if v, err := p2.Await(); v != 42 || err != nil {
+		t.Errorf("Shared promise result is wrong: %v %v", v, err)
+	}
+	p3.Await()
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Function called %d times", calls)
+	}
+	// Without a TTL the finished promise is dropped, so the next Do calls fn again
+	time.Sleep(10 * time.Millisecond)
+	if p4 := g.Do("a", fn); p4 == p1 {
+		t.Error("Finished promise was reused without a TTL")
+	}
+}
+
+func TestGroupForget(t *testing.T) {
+	var g promise.Group[int, int]
+	release := make(chan struct{})
+	p1 := g.Do(1, func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	g.Forget(1)
+	p2 := g.Do(1, func() (int, error) {
+		return 2, nil
+	})
+	close(release)
+	if p1 == p2 {
+		t.Error("Forget didn't forget the promise")
+	}
+	if v, _ := p1.Await(); v != 1 {
+		t.Errorf("Forgotten promise result is wrong: %d", v)
+	}
+	if v, _ := p2.Await(); v != 2 {
+		t.Errorf("New promise result is wrong: %d", v)
+	}
+}
+
+func TestGroupTTL(t *testing.T) {
+	g := promise.Group[string, int]{TTL: 50 * time.Millisecond}
+	var calls int32
+	fn := func() (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	}
+	p1 := g.Do("a", fn)
+	p1.Await()
+	time.Sleep(10 * time.Millisecond)
+	if p2 := g.Do("a", fn); p2 != p1 {
+		t.Error("Resolved promise wasn't cached")
+	}
+	time.Sleep(80 * time.Millisecond)
+	if v, _ := g.Do("a", fn).Await(); v != 2 {
+		t.Errorf("Cached promise didn't expire: %d", v)
+	}
+
+	failed := errors.New("failed")
+	p3 := g.Do("b", func() (int, error) { return 0, failed })
+	p3.Await()
+	time.Sleep(10 * time.Millisecond)
+	if p4 := g.Do("b", fn); p4 == p3 {
+		t.Error("Rejected promise was cached")
+	}
+}
@@ -0,0 +1,153 @@
+package promise
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zafnz/go-missing"
+)
+
+var (
+	// Submit returns a promise rejected with ErrPoolClosed if the pool has been shut down.
+	ErrPoolClosed = errors.New("promise: pool is shut down")
+	// Submit returns a promise rejected with ErrQueueFull if the pool's queue has no room.
+	ErrQueueFull = errors.New("promise: pool queue is full")
+)
+
+// A Pool runs promise functions on a fixed number of goroutines, rather than the goroutine per promise that New
+// uses. Functions submitted while every goroutine is busy wait in a queue of limited size, and once that is full
+// Submit rejects immediately with ErrQueueFull, so a burst of work can't create an unbounded number of goroutines.
+//
+//    pool := promise.NewPool(10, 1000)
+//    defer pool.Shutdown()
+//    var results []*promise.Promise[Row]
+//    for _, id := range ids {
+//        id := id
+//        results = append(results, promise.Submit(pool, func() (Row, error) {
+//            return fetchRow(id)
+//        }))
+//    }
+//    rows, err := promise.All(results...).Await()
+type Pool struct {
+	tasks   chan func()
+	mu      sync.Mutex
+	idle    *sync.Cond // Signalled when pending drops to zero
+	pending int        // Tasks submitted but not yet finished
+	closed  bool
+	workers sync.WaitGroup
+
+	queued    int64
+	running   int64
+	completed int64
+}
+
+// A snapshot of what a Pool is doing, see Pool.Stats.
+type PoolStats struct {
+	Queued    int64 // Functions waiting for a goroutine
+	Running   int64 // Functions currently running
+	Completed int64 // Functions that have finished (including those whose promise was cancelled while queued)
+}
+
+// Creates a pool that runs at most maxConcurrency functions at once (at least 1), with up to queueLimit more
+// waiting for a turn. With a queueLimit of 0, Submit only succeeds if a goroutine is free right then.
+func NewPool(maxConcurrency int, queueLimit int) *Pool {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	if queueLimit < 0 {
+		queueLimit = 0
+	}
+	p := &Pool{tasks: make(chan func(), queueLimit)}
+	p.idle = sync.NewCond(&p.mu)
+	p.workers.Add(maxConcurrency)
+	for i := 0; i < maxConcurrency; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Runs the supplied function on the pool, returning a promise for it's result, in the same way as New. If the pool
+// is shut down or it's queue is full, the returned promise is already rejected with ErrPoolClosed or ErrQueueFull.
+// If the promise is cancelled while still queued, the function is never run.
+//
+// (This is a package function rather than a method because go methods can't introduce new type parameters.)
+func Submit[T any](pool *Pool, fn func() (T, error)) *Promise[T] {
+	p := &Promise[T]{done: make(chan struct{})}
+	task := func() {
+		select {
+		case <-p.done:
+			return // Cancelled while waiting in the queue
+		default:
+		}
+		v, err := missing.CatchPanic(fn)
+		if err != nil {
+			p.reject(err)
+		} else {
+			p.resolve(v)
+		}
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.closed {
+		return Reject[T](ErrPoolClosed)
+	}
+	atomic.AddInt64(&pool.queued, 1)
+	select {
+	case pool.tasks <- task:
+		pool.pending++
+		return p
+	default:
+		atomic.AddInt64(&pool.queued, -1)
+		return Reject[T](ErrQueueFull)
+	}
+}
+
+// Waits until every function submitted to the pool so far has finished. The pool can still be used afterwards.
+func (pool *Pool) Wait() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for pool.pending > 0 {
+		pool.idle.Wait()
+	}
+}
+
+// Stops the pool accepting new functions, then waits for those already queued or running to finish before
+// releasing the pool's goroutines. It is safe to call Shutdown more than once.
+func (pool *Pool) Shutdown() {
+	pool.mu.Lock()
+	if !pool.closed {
+		pool.closed = true
+		close(pool.tasks)
+	}
+	pool.mu.Unlock()
+	pool.workers.Wait()
+}
+
+// Returns the number of queued, running and completed functions.
+func (pool *Pool) Stats() PoolStats {
+	return PoolStats{
+		Queued:    atomic.LoadInt64(&pool.queued),
+		Running:   atomic.LoadInt64(&pool.running),
+		Completed: atomic.LoadInt64(&pool.completed),
+	}
+}
+
+func (pool *Pool) work() {
+	defer pool.workers.Done()
+	for task := range pool.tasks {
+		atomic.AddInt64(&pool.queued, -1)
+		atomic.AddInt64(&pool.running, 1)
+		task()
+		atomic.AddInt64(&pool.running, -1)
+		atomic.AddInt64(&pool.completed, 1)
+
+		pool.mu.Lock()
+		pool.pending--
+		if pool.pending == 0 {
+			pool.idle.Broadcast()
+		}
+		pool.mu.Unlock()
+	}
+}
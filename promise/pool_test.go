@@ -0,0 +1,94 @@
+package promise_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing/promise"
+)
+
+func TestPool(t *testing.T) {
+	pool := promise.NewPool(3, 100)
+	defer pool.Shutdown()
+
+	var running, most int32
+	var promises []*promise.Promise[int]
+	for i := 0; i < 20; i++ {
+		i := i
+		promises = append(promises, promise.Submit(pool, func() (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&most)
+				if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return i * 2, nil
+		}))
+	}
+	vals, err := promise.All(promises...).Await()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range vals {
+		if v != i*2 {
+			t.Fatalf("Pool promise %d resolved with %d", i, v)
+		}
+	}
+	if most > 3 {
+		t.Errorf("Pool ran %d functions at once, limit is 3", most)
+	}
+	pool.Wait()
+	stats := pool.Stats()
+	if stats.Completed != 20 || stats.Queued != 0 || stats.Running != 0 {
+		t.Errorf("Pool stats are wrong: %+v", stats)
+	}
+}
+
+func TestPoolQueueFull(t *testing.T) {
+	pool := promise.NewPool(1, 1)
+	defer pool.Shutdown()
+	release := make(chan struct{})
+	block := func() (int, error) {
+		<-release
+		return 0, nil
+	}
+	promise.Submit(pool, block)
+	// Wait for the first to start running, so the second sits in the queue.
+	for pool.Stats().Running != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	promise.Submit(pool, block)
+	_, err := promise.Submit(pool, block).Await()
+	if err != promise.ErrQueueFull {
+		t.Errorf("Submitting to a full queue didn't reject with ErrQueueFull: %v", err)
+	}
+	if stats := pool.Stats(); stats.Queued != 1 {
+		t.Errorf("Pool should have one queued: %+v", stats)
+	}
+	close(release)
+}
+
+func TestPoolShutdown(t *testing.T) {
+	pool := promise.NewPool(1, 10)
+	var ran int32
+	for i := 0; i < 5; i++ {
+		promise.Submit(pool, func() (int, error) {
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&ran, 1)
+			return 0, nil
+		})
+	}
+	pool.Shutdown()
+	if atomic.LoadInt32(&ran) != 5 {
+		t.Errorf("Shutdown didn't wait for queued functions: %d ran", ran)
+	}
+	_, err := promise.Submit(pool, func() (int, error) { return 0, nil }).Await()
+	if err != promise.ErrPoolClosed {
+		t.Errorf("Submit after Shutdown didn't reject with ErrPoolClosed: %v", err)
+	}
+	pool.Shutdown() // Safe to call twice
+}
@@ -3,9 +3,15 @@
 package promise
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/zafnz/go-missing"
 )
 
 // A promise will execute immediately, and the result of the promise (the returned value or error) can be
@@ -17,6 +23,8 @@ type Promise[T any] struct {
 	err      error
 	finished bool
 	done     chan struct{}
+	mu       sync.Mutex
+	cancel   context.CancelFunc // Cancels the context of a NewCtx promise, nil otherwise
 }
 
 var closedChan = make(chan struct{})
@@ -31,7 +39,8 @@ func init() {
 //
 // Note: Unlike javascript promises, the callback function is not supplied a resolve() and reject() function
 // to call. This is because those don't make sense with the way golang works. When it is time to resolve the
-// promise, simply return.
+// promise, simply return. If the function panics, the promise rejects with a *missing.PanicError rather than
+// crashing the program (see missing.RecoverPanics).
 //
 // Example:
 //    p := promise.New(func() (string, error) {
@@ -43,16 +52,64 @@ func New[T any](fn func() (T, error)) *Promise[T] {
 	p := Promise[T]{}
 	p.done = make(chan struct{})
 	go func() {
-		v, err := fn()
+		v, err := missing.CatchPanic(fn)
+		if err != nil {
+			p.reject(err)
+		} else {
+			p.resolve(v)
+		}
+	}()
+	return &p
+}
+
+// Returns a new promise, like New, but the supplied function is passed a context derived from ctx. If ctx is
+// cancelled (or the promise's Cancel method is called) before the function returns, the promise immediately rejects
+// with ctx.Err() and the function's context is cancelled so that it can stop early. The function's context is
+// also cancelled once the promise has resolved or rejected.
+//
+// A function that ignores it's context keeps running until it returns, and it's result is discarded. Cancelling a
+// promise is normal use (Race and Any cancel the losers), so unlike missing.TimeoutCtxErr these results are not
+// handed to missing.OnAbandoned or counted by missing.AbandonedCalls.
+//
+//    p := promise.NewCtx(req.Context(), func(ctx context.Context) ([]byte, error) {
+//        return fetchReport(ctx)
+//    })
+//    report, err := p.Await() // err is context.Canceled if the client went away
+func NewCtx[T any](ctx context.Context, fn func(context.Context) (T, error)) *Promise[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	p := Promise[T]{}
+	p.done = make(chan struct{})
+	p.cancel = cancel
+	go func() {
+		v, err := missing.CatchPanic(func() (T, error) {
+			return fn(ctx)
+		})
 		if err != nil {
 			p.reject(err)
 		} else {
 			p.resolve(v)
 		}
 	}()
+	go func() {
+		// Once the promise finishes release() cancels ctx, so this always returns, and the reject does nothing.
+		<-ctx.Done()
+		p.reject(ctx.Err())
+	}()
 	return &p
 }
 
+// Returns a promise that calls the supplied function, retrying with backoff until it succeeds, as missing.Retry
+// does. Cancelling ctx, or the promise, stops any further attempts and cancels the current one's context.
+//
+//    p := promise.Retry(ctx, missing.RetryOptions{Attempts: 5}, func(ctx context.Context) (Row, error) {
+//        return db.FetchRow(ctx, id)
+//    })
+func Retry[T any](ctx context.Context, opts missing.RetryOptions, fn func(context.Context) (T, error)) *Promise[T] {
+	return NewCtx(ctx, func(ctx context.Context) (T, error) {
+		return missing.Retry(ctx, opts, fn)
+	})
+}
+
 // Returns a promise that resolves with the provided value.
 func Resolve[T any](val T) *Promise[T] {
 	return &Promise[T]{
@@ -83,8 +140,8 @@ func (p *Promise[T]) Await() (T, error) {
 }
 
 // Calls the supplied function when the promise has resolved, and returns a promise that will resolve when
-// the supplied function finishes (allowing for chaining). Note: There is no Catch(), it doesn't really align
-// with how go works.
+// the supplied function finishes (allowing for chaining). The function is called whether the promise resolved or
+// rejected; see Catch and Recover for functions that only handle errors, and Map for changing the type.
 func (p *Promise[T]) Then(fn func(T, error) (T, error)) *Promise[T] {
 	next := New(func() (T, error) {
 		<-p.Done()
@@ -93,6 +150,74 @@ func (p *Promise[T]) Then(fn func(T, error) (T, error)) *Promise[T] {
 	return next
 }
 
+// Calls the supplied function only if the promise rejects, and returns a promise that resolves or rejects with
+// whatever the function returns. If the promise resolves then the returned promise resolves with the same value,
+// without calling the function.
+//
+//    p := fetchConfig().Catch(func(err error) (Config, error) {
+//        if errors.Is(err, fs.ErrNotExist) {
+//            return DefaultConfig, nil // Handled, carry on with the defaults
+//        }
+//        return Config{}, err // Anything else is still an error
+//    })
+func (p *Promise[T]) Catch(fn func(error) (T, error)) *Promise[T] {
+	return New(func() (T, error) {
+		v, err := p.Await()
+		if err != nil {
+			return fn(err)
+		}
+		return v, nil
+	})
+}
+
+// Like Catch, but the supplied function always converts the error into a value, so the returned promise always
+// resolves.
+func (p *Promise[T]) Recover(fn func(error) T) *Promise[T] {
+	return p.Catch(func(err error) (T, error) {
+		return fn(err), nil
+	})
+}
+
+// Calls the supplied function with the value of the promise once it resolves, and returns a promise of whatever
+// type the function returns. This is Then for when the type changes; go methods can't introduce new type parameters,
+// so it is a package function. If the promise rejects, the function isn't called and the returned promise rejects
+// with the same error.
+//
+// Example:
+//    body := promise.New(func() ([]byte, error) { return fetch(url) })
+//    user := promise.Map(body, func(b []byte) (User, error) {
+//        var u User
+//        err := json.Unmarshal(b, &u)
+//        return u, err
+//    })
+func Map[T, U any](p *Promise[T], fn func(T) (U, error)) *Promise[U] {
+	return New(func() (U, error) {
+		v, err := p.Await()
+		if err != nil {
+			var u U
+			return u, err
+		}
+		return fn(v)
+	})
+}
+
+// Like Map, but for functions that themselves return a promise. The returned promise resolves or rejects with the
+// promise returned by the function (so you don't end up with a promise of a promise).
+//
+//    orders := promise.FlatMap(user, func(u User) *promise.Promise[[]Order] {
+//        return fetchOrders(u.ID) // Returns a *promise.Promise[[]Order]
+//    })
+func FlatMap[T, U any](p *Promise[T], fn func(T) *Promise[U]) *Promise[U] {
+	return New(func() (U, error) {
+		v, err := p.Await()
+		if err != nil {
+			var u U
+			return u, err
+		}
+		return fn(v).Await()
+	})
+}
+
 // Done returns a channel that's closed when the promise has resolved or rejected. Successive calls to Done return the
 // same value, and calling Done on a returned promise will return an immediately closed channel. This is the best
 // wait to wait for a promise to resolve without calling Await.
@@ -114,11 +239,22 @@ func (p *Promise[T]) Done() chan struct{} {
 	return p.done
 }
 
+// Cancels the promise, which rejects with context.Canceled if it hasn't already resolved or rejected. If the promise
+// was created with NewCtx then the function's context is cancelled as well, otherwise the function keeps running but
+// it's result is ignored. Cancelling a finished promise does nothing.
+func (p *Promise[T]) Cancel() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.reject(context.Canceled)
+}
+
 func (p *Promise[T]) String() string {
 	return fmt.Sprintf("Promise.%T", p.value)
 }
 
-// Returns a promise that resolves to the first value from the supplied promises.
+// Returns a promise that resolves to the first value from the supplied promises. Once a promise has won the race, the
+// rest are cancelled (see Cancel).
 //
 // Example:
 //    a := promise.New(func() (int, error) { return 10, nil })
@@ -137,10 +273,91 @@ func Race[T any](promises ...*Promise[T]) *Promise[T] {
 			}(idx, p)
 		}
 		idx := <-ch
+		for _, p := range promises {
+			p.Cancel()
+		}
 		return promises[idx].value, promises[idx].err
 	})
 }
 
+// Returns a promise that resolves with the value of the first supplied promise to resolve, ignoring any that reject.
+// Compare to Race, which also finishes on the first rejection. Only if every promise rejects does the returned promise
+// reject, with an *AggregateError holding each promise's error. Once a promise has resolved the rest are cancelled.
+//
+// Example:
+//    // Whichever replica answers successfully first
+//    val, err := promise.Any(fetchFrom(replicaA), fetchFrom(replicaB)).Await()
+func Any[T any](promises ...*Promise[T]) *Promise[T] {
+	return New(func() (T, error) {
+		ch := make(chan int, len(promises))
+		for idx, p := range promises {
+			go func(idx int, p *Promise[T]) {
+				<-p.Done()
+				ch <- idx
+			}(idx, p)
+		}
+		errs := make([]error, len(promises))
+		for i := 0; i < len(promises); i++ {
+			idx := <-ch
+			v, err := promises[idx].Await()
+			if err == nil {
+				for _, p := range promises {
+					p.Cancel()
+				}
+				return v, nil
+			}
+			errs[idx] = err
+		}
+		var t T
+		return t, &AggregateError{Errors: errs}
+	})
+}
+
+// The error from Any when every promise rejected. Errors holds each promise's error, in the order the promises were
+// supplied. It works with errors.Is and errors.As, which match if any of the errors match.
+type AggregateError struct {
+	Errors []error
+}
+
+// Returns the error messages, one per line, the same as errors.Join would.
+func (e *AggregateError) Error() string {
+	if len(e.Errors) == 0 {
+		return "all promises were rejected"
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Returns the errors that make up the AggregateError, for errors.Is and errors.As.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// Returns true if any of the errors match target. Go versions before 1.20 don't look at Unwrap() []error, so
+// errors.Is uses this instead.
+func (e *AggregateError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the first of the errors that matches target, and sets target to it. Go versions before 1.20 don't look at
+// Unwrap() []error, so errors.As uses this instead.
+func (e *AggregateError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns a promise that resolves when all the supplied promises have resolved. The returned promise's value type
 // is an array of the promise value types. If any promise returns an error then the returned promise rejects
 // immediately. The return order in the array matches the promise order supplied.
@@ -174,37 +391,112 @@ func All[T any](promises ...*Promise[T]) *Promise[[]T] {
 	})
 }
 
+// The outcome of a single promise, as returned by AllSettled. Err is nil if the promise resolved.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Returns a promise that resolves once every supplied promise has either resolved or rejected. Unlike All, it never
+// rejects and never stops early: the value is a slice holding the outcome of each promise, in the order the promises
+// were supplied.
+//
+// Example:
+//    results, _ := promise.AllSettled(checkDb, checkCache, checkQueue).Await()
+//    for i, r := range results {
+//        if r.Err != nil {
+//            fmt.Printf("Backend %d is unhealthy: %s\n", i, r.Err)
+//        }
+//    }
+func AllSettled[T any](promises ...*Promise[T]) *Promise[[]Result[T]] {
+	return New(func() ([]Result[T], error) {
+		results := make([]Result[T], len(promises))
+		for idx, p := range promises {
+			v, err := p.Await()
+			results[idx] = Result[T]{Value: v, Err: err}
+		}
+		return results, nil
+	})
+}
+
+// Returns a Seq2 of the index and Result of each promise, in the order the promises finish rather than the order they
+// were supplied. Stopping early doesn't cancel the promises that are still running.
+//
+// Example:
+//    promise.AsCompleted(checkDb, checkCache)(func(idx int, r promise.Result[bool]) bool {
+//        fmt.Printf("Check %d finished: %v\n", idx, r.Err)
+//        return true
+//    })
+func AsCompleted[T any](promises ...*Promise[T]) missing.Seq2[int, Result[T]] {
+	return func(yield func(int, Result[T]) bool) {
+		ch := make(chan int, len(promises))
+		for idx, p := range promises {
+			go func(idx int, p *Promise[T]) {
+				<-p.Done()
+				ch <- idx
+			}(idx, p)
+		}
+		for i := 0; i < len(promises); i++ {
+			idx := <-ch
+			v, err := promises[idx].Await()
+			if !yield(idx, Result[T]{Value: v, Err: err}) {
+				return
+			}
+		}
+	}
+}
+
 // Returns a promise that will error with os.ErrDeadlineExceeded when the supplied duration elapses.
 // This can be combined with promise.Race to run a function that times out. However be cautious as the
-// other function will still keep running even after the Race has returned the timeout.
+// other function will still keep running even after the Race has returned the timeout, unless it was
+// created with NewCtx and watches it's context. Cancelling a Timeout promise stops it's timer.
 //
 // This function, like promise.Reject, will need to specify the promise type:
 //   promise.Timeout[float64](time.Second * 5)
 //
 // See Dome() for a channel that is a better way to do this, especially with contexts.
 func Timeout[T any](duration time.Duration) *Promise[T] {
-	return New(func() (T, error) {
+	return NewCtx(context.Background(), func(ctx context.Context) (T, error) {
 		var t T
-		time.Sleep(duration)
-		return t, os.ErrDeadlineExceeded
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return t, os.ErrDeadlineExceeded
+		case <-ctx.Done():
+			return t, ctx.Err()
+		}
 	})
 }
 
 // Internal functions that resolve/reject the promises
 
 func (p *Promise[T]) resolve(v T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if p.finished {
 		return
 	}
 	p.value = v
 	p.finished = true
 	close(p.done)
+	p.release()
 }
 func (p *Promise[T]) reject(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if p.finished {
 		return
 	}
 	p.err = err
 	p.finished = true
 	close(p.done)
+	p.release()
+}
+
+// Releases the context of a NewCtx promise once it has finished.
+func (p *Promise[T]) release() {
+	if p.cancel != nil {
+		p.cancel()
+	}
 }
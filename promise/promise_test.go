@@ -1,12 +1,14 @@
 package promise_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/zafnz/go-missing"
 	"github.com/zafnz/go-missing/promise"
 )
 
@@ -231,3 +233,302 @@ func TestString(t *testing.T) {
 		t.Errorf("String is incorrect: %s", str)
 	}
 }
+
+func TestPanic(t *testing.T) {
+	p := promise.New(func() (int, error) {
+		panic("blerg")
+	})
+	_, err := p.Await()
+	var perr *missing.PanicError
+	if !errors.As(err, &perr) || perr.Value != "blerg" {
+		t.Errorf("Panicking promise didn't reject with a PanicError: %v", err)
+	}
+}
+
+func TestNewCtx(t *testing.T) {
+	v, err := promise.NewCtx(context.Background(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	}).Await()
+	if v != 42 || err != nil {
+		t.Errorf("NewCtx didn't resolve: %d %v", v, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = promise.NewCtx(ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(300 * time.Millisecond)
+		return 42, nil
+	}).Await()
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	stopped := make(chan struct{})
+	p := promise.NewCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	p.Cancel()
+	if _, err := p.Await(); err != context.Canceled {
+		t.Errorf("Cancelled promise didn't reject with context.Canceled: %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Cancelling the promise didn't cancel the function's context")
+	}
+
+	// Cancelling a finished promise does nothing
+	r := promise.Resolve(42)
+	r.Cancel()
+	if v, err := r.Await(); v != 42 || err != nil {
+		t.Errorf("Cancel changed a resolved promise: %d %v", v, err)
+	}
+
+	// Plain promises can be cancelled too, the function just keeps running.
+	n := promise.New(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 42, nil
+	})
+	n.Cancel()
+	if _, err := n.Await(); err != context.Canceled {
+		t.Errorf("Cancelled promise didn't reject with context.Canceled: %v", err)
+	}
+}
+
+// Cancelling the losers is normal use, not an abandoned call, so it mustn't reach the OnAbandoned hook.
+func TestRaceTimeoutNotAbandoned(t *testing.T) {
+	var calls int32
+	missing.OnAbandoned(func(val any, err error) {
+		if errors.Is(err, context.Canceled) {
+			atomic.AddInt32(&calls, 1)
+		}
+	})
+	defer missing.OnAbandoned(nil)
+	for i := 0; i < 10; i++ {
+		v, err := promise.Race(promise.Resolve(1), promise.Timeout[int](time.Hour)).Await()
+		if v != 1 || err != nil {
+			t.Fatalf("Race is wrong: %d %v", v, err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("OnAbandoned was called %d times for cancelled promises", n)
+	}
+}
+
+func TestRaceCancelsLosers(t *testing.T) {
+	stopped := make(chan struct{})
+	slow := promise.NewCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	v, _ := promise.Race(slow, promise.Resolve(42)).Await()
+	if v != 42 {
+		t.Errorf("Race finished %d", v)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Race didn't cancel the losing promise")
+	}
+	if _, err := slow.Await(); err != context.Canceled {
+		t.Errorf("Losing promise didn't reject with context.Canceled: %v", err)
+	}
+}
+
+func TestAllSettled(t *testing.T) {
+	blerg := errors.New("blerg")
+	a := promise.New(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 42, nil
+	})
+	b := promise.Reject[int](blerg)
+	c := promise.New(func() (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 55, nil
+	})
+	results, err := promise.AllSettled(a, b, c).Await()
+	if err != nil {
+		t.Fatalf("AllSettled rejected: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Wrong number of results: %v", results)
+	}
+	if results[0].Value != 42 || results[0].Err != nil {
+		t.Errorf("First result is wrong: %v", results[0])
+	}
+	if results[1].Err != blerg {
+		t.Errorf("Second result didn't keep it's error: %v", results[1])
+	}
+	if results[2].Value != 55 || results[2].Err != nil {
+		t.Errorf("Third result is wrong: %v", results[2])
+	}
+
+	empty, _ := promise.AllSettled[int]().Await()
+	if len(empty) != 0 {
+		t.Errorf("AllSettled of nothing isn't empty: %v", empty)
+	}
+}
+
+func TestAny(t *testing.T) {
+	fastFail := promise.Reject[int](errors.New("fast failure"))
+	slowOk := promise.New(func() (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 42, nil
+	})
+	v, err := promise.Any(fastFail, slowOk).Await()
+	if v != 42 || err != nil {
+		t.Errorf("Any didn't resolve with the successful promise: %d %v", v, err)
+	}
+
+	blerg := errors.New("blerg")
+	splat := errors.New("splat")
+	_, err = promise.Any(promise.Reject[int](blerg), promise.New(func() (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 0, splat
+	})).Await()
+	var agg *promise.AggregateError
+	if !errors.As(err, &agg) {
+		t.Fatalf("Any didn't reject with an AggregateError: %v", err)
+	}
+	if len(agg.Errors) != 2 || agg.Errors[0] != blerg || agg.Errors[1] != splat {
+		t.Errorf("AggregateError has the wrong errors: %v", agg.Errors)
+	}
+	if !errors.Is(err, blerg) || !errors.Is(err, splat) {
+		t.Error("errors.Is doesn't find the causes in the AggregateError")
+	}
+	if err.Error() != "blerg\nsplat" {
+		t.Errorf("AggregateError message is wrong: %q", err.Error())
+	}
+	// Go before 1.20 only finds the causes through the Is and As methods
+	if !agg.Is(splat) || agg.Is(errors.New("blerg")) {
+		t.Error("AggregateError.Is is wrong")
+	}
+	panicked := &promise.AggregateError{Errors: []error{blerg, &missing.PanicError{Value: "oops"}}}
+	var perr *missing.PanicError
+	if !panicked.As(&perr) || perr.Value != "oops" {
+		t.Errorf("AggregateError.As is wrong: %v", perr)
+	}
+}
+
+func ExampleMap() {
+	body := promise.Resolve([]byte("42"))
+	num := promise.Map(body, func(b []byte) (int, error) {
+		var n int
+		_, err := fmt.Sscan(string(b), &n)
+		return n, err
+	})
+	v, _ := num.Await()
+	fmt.Println(v + 1)
+	// Output: 43
+}
+
+func TestMap(t *testing.T) {
+	called := false
+	blerg := errors.New("blerg")
+	_, err := promise.Map(promise.Reject[int](blerg), func(i int) (string, error) {
+		called = true
+		return "", nil
+	}).Await()
+	if err != blerg || called {
+		t.Errorf("Map of a rejected promise didn't pass the error through: %v %t", err, called)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	p := promise.FlatMap(promise.Resolve(21), func(i int) *promise.Promise[string] {
+		return promise.New(func() (string, error) {
+			return fmt.Sprint(i * 2), nil
+		})
+	})
+	v, err := p.Await()
+	if v != "42" || err != nil {
+		t.Errorf("FlatMap didn't resolve with the inner promise: %q %v", v, err)
+	}
+
+	blerg := errors.New("blerg")
+	_, err = promise.FlatMap(promise.Resolve(21), func(i int) *promise.Promise[string] {
+		return promise.Reject[string](blerg)
+	}).Await()
+	if err != blerg {
+		t.Errorf("FlatMap didn't reject with the inner promise's error: %v", err)
+	}
+}
+
+func TestCatch(t *testing.T) {
+	blerg := errors.New("blerg")
+	v, err := promise.Reject[int](blerg).Catch(func(err error) (int, error) {
+		if err != blerg {
+			t.Errorf("Catch got the wrong error: %v", err)
+		}
+		return 42, nil
+	}).Await()
+	if v != 42 || err != nil {
+		t.Errorf("Catch didn't convert the error into a value: %d %v", v, err)
+	}
+
+	v, err = promise.Resolve(55).Catch(func(err error) (int, error) {
+		t.Error("Catch called for a resolved promise")
+		return 0, err
+	}).Await()
+	if v != 55 || err != nil {
+		t.Errorf("Catch changed a resolved promise: %d %v", v, err)
+	}
+
+	v, err = promise.Reject[int](blerg).Recover(func(err error) int {
+		return 69
+	}).Await()
+	if v != 69 || err != nil {
+		t.Errorf("Recover didn't convert the error into a value: %d %v", v, err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls int32
+	v, err := promise.Retry(context.Background(), missing.RetryOptions{Delay: time.Millisecond},
+		func(ctx context.Context) (int, error) {
+			if atomic.AddInt32(&calls, 1) < 2 {
+				return 0, errors.New("not yet")
+			}
+			return 42, nil
+		}).Await()
+	if v != 42 || err != nil {
+		t.Errorf("Retry promise didn't resolve: %d %v", v, err)
+	}
+}
+
+func TestAsCompleted(t *testing.T) {
+	slow := promise.New(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	})
+	fast := promise.New(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 2, nil
+	})
+	var order []int
+	promise.AsCompleted(slow, fast, promise.Reject[int](errors.New("blerg")))(func(idx int, r promise.Result[int]) bool {
+		order = append(order, idx)
+		if idx == 0 && r.Value != 1 {
+			t.Errorf("Wrong result for the slow promise: %v", r)
+		}
+		return true
+	})
+	if fmt.Sprint(order) != "[2 1 0]" {
+		t.Errorf("AsCompleted didn't yield in completion order: %v", order)
+	}
+
+	count := 0
+	promise.AsCompleted(slow, fast)(func(idx int, r promise.Result[int]) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("AsCompleted didn't stop early: %d", count)
+	}
+}
@@ -0,0 +1,94 @@
+package promise
+
+// Typed versions of All, for waiting on promises of different types at once. Go generics have no variadic type
+// parameters, so there is one function per number of promises.
+
+// The values of two promises, as returned by All2.
+type Tuple2[T1, T2 any] struct {
+	V1 T1
+	V2 T2
+}
+
+// The values of three promises, as returned by All3.
+type Tuple3[T1, T2, T3 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+}
+
+// The values of four promises, as returned by All4.
+type Tuple4[T1, T2, T3, T4 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+}
+
+// Returns a promise that resolves when both the supplied promises have resolved, with their values in a Tuple2.
+// Like All, if either promise rejects then the returned promise rejects immediately with that error.
+//
+// Example:
+//    user := promise.New(func() (User, error) { return lookupUser(id) })
+//    orders := promise.New(func() ([]Order, error) { return lookupOrders(id) })
+//    both, err := promise.All2(user, orders).Await()
+//    if err != nil {
+//        return err
+//    }
+//    render(both.V1, both.V2)
+func All2[T1, T2 any](p1 *Promise[T1], p2 *Promise[T2]) *Promise[Tuple2[T1, T2]] {
+	return New(func() (Tuple2[T1, T2], error) {
+		if err := awaitAll(p1, p2); err != nil {
+			return Tuple2[T1, T2]{}, err
+		}
+		return Tuple2[T1, T2]{p1.value, p2.value}, nil
+	})
+}
+
+// The same as All2, but for three promises.
+func All3[T1, T2, T3 any](p1 *Promise[T1], p2 *Promise[T2], p3 *Promise[T3]) *Promise[Tuple3[T1, T2, T3]] {
+	return New(func() (Tuple3[T1, T2, T3], error) {
+		if err := awaitAll(p1, p2, p3); err != nil {
+			return Tuple3[T1, T2, T3]{}, err
+		}
+		return Tuple3[T1, T2, T3]{p1.value, p2.value, p3.value}, nil
+	})
+}
+
+// The same as All2, but for four promises.
+func All4[T1, T2, T3, T4 any](p1 *Promise[T1], p2 *Promise[T2], p3 *Promise[T3], p4 *Promise[T4]) *Promise[Tuple4[T1, T2, T3, T4]] {
+	return New(func() (Tuple4[T1, T2, T3, T4], error) {
+		if err := awaitAll(p1, p2, p3, p4); err != nil {
+			return Tuple4[T1, T2, T3, T4]{}, err
+		}
+		return Tuple4[T1, T2, T3, T4]{p1.value, p2.value, p3.value, p4.value}, nil
+	})
+}
+
+// A promise of any type, so promises of different types can be waited on together.
+type settler interface {
+	Done() chan struct{}
+	failure() error
+}
+
+// Returns the promise's error. Only valid once the promise is done.
+func (p *Promise[T]) failure() error {
+	return p.err
+}
+
+// Waits for all the supplied promises to resolve, or returns the error of the first to reject.
+func awaitAll(promises ...settler) error {
+	ch := make(chan int, len(promises))
+	for idx, p := range promises {
+		go func(idx int, p settler) {
+			<-p.Done()
+			ch <- idx
+		}(idx, p)
+	}
+	for i := 0; i < len(promises); i++ {
+		idx := <-ch
+		if err := promises[idx].failure(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package promise_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing/promise"
+)
+
+func TestAll2(t *testing.T) {
+	name := promise.New(func() (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "Arthur", nil
+	})
+	age := promise.Resolve(42)
+	both, err := promise.All2(name, age).Await()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if both.V1 != "Arthur" || both.V2 != 42 {
+		t.Errorf("All2 has the wrong values: %+v", both)
+	}
+}
+
+func TestAll3FailsFast(t *testing.T) {
+	blerg := errors.New("blerg")
+	slow := promise.New(func() (string, error) {
+		time.Sleep(time.Second)
+		return "slow", nil
+	})
+	start := time.Now()
+	_, err := promise.All3(slow, promise.Reject[int](blerg), promise.Resolve(4.2)).Await()
+	if err != blerg {
+		t.Errorf("All3 didn't reject with the error: %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("All3 waited for the slow promise after another rejected")
+	}
+}
+
+func TestAll4(t *testing.T) {
+	all, err := promise.All4(promise.Resolve(1), promise.Resolve("two"), promise.Resolve(3.0), promise.Resolve([]int{4})).Await()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.V1 != 1 || all.V2 != "two" || all.V3 != 3.0 || all.V4[0] != 4 {
+		t.Errorf("All4 has the wrong values: %+v", all)
+	}
+}
@@ -0,0 +1,120 @@
+package missing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Options for Retry. The zero value is usable: 3 attempts, starting with a 100ms delay that doubles each time.
+type RetryOptions struct {
+	Attempts       int              // Total number of attempts, including the first. Defaults to 3.
+	Delay          time.Duration    // How long to wait before the second attempt. Defaults to 100ms.
+	MaxDelay       time.Duration    // The longest to wait between attempts, however big the backoff gets. 0 is no limit.
+	Multiplier     float64          // The delay is multiplied by this after each attempt. Defaults to 2, use 1 for a fixed delay.
+	Jitter         float64          // Randomly shortens each delay by up to this fraction (0 to 1), so callers don't retry in lockstep.
+	AttemptTimeout time.Duration    // Gives up on a single attempt after this long, and tries again. 0 is no limit.
+	Retryable      func(error) bool // Returns true if an error is worth retrying. If nil, every error is retried.
+}
+
+// The error returned by Retry when it gives up. Err is the last error, or the context's error if it was cancelled, in
+// which case Cause holds the last attempt's error. errors.Is and errors.As see through to both.
+type RetryError struct {
+	Attempts int
+	Err      error
+	Cause    error // The last attempt's error, if Err is the context's error. Nil otherwise.
+}
+
+func (e *RetryError) Error() string {
+	msg := fmt.Sprintf("gave up after %d attempt%s: %s", e.Attempts, If(e.Attempts == 1, "", "s"), e.Err)
+	if e.Cause != nil {
+		msg += fmt.Sprintf(" (last error: %s)", e.Cause)
+	}
+	return msg
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Returns true if the last attempt's error matches target, when it's held in Cause. (errors.Is checks Err itself
+// through Unwrap.)
+func (e *RetryError) Is(target error) bool {
+	return e.Cause != nil && errors.Is(e.Cause, target)
+}
+
+// Sets target to the last attempt's error if it matches, when it's held in Cause. (errors.As checks Err itself
+// through Unwrap.)
+func (e *RetryError) As(target any) bool {
+	return e.Cause != nil && errors.As(e.Cause, target)
+}
+
+// Calls the supplied function until it succeeds, waiting longer between each attempt (exponential backoff). It gives
+// up, returning a *RetryError wrapping the last error, once it has made opts.Attempts attempts, the function returns
+// an error that opts.Retryable rejects, or ctx is cancelled. Each attempt is run with TimeoutCtxErr, so the function
+// is passed a context that is cancelled if ctx is, or if opts.AttemptTimeout passes, and panics are returned as
+// errors (and retried, unless opts.Retryable says otherwise).
+//
+// Example:
+//   resp, err := missing.Retry(ctx, missing.RetryOptions{Attempts: 5, AttemptTimeout: 2 * time.Second},
+//   	func(ctx context.Context) (*http.Response, error) {
+//   		req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+//   		return http.DefaultClient.Do(req)
+//   	})
+func Retry[T any](ctx context.Context, opts RetryOptions, fn func(context.Context) (T, error)) (T, error) {
+	if opts.Attempts < 1 {
+		opts.Attempts = 3
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 100 * time.Millisecond
+	}
+	if opts.Multiplier <= 0 {
+		opts.Multiplier = 2
+	}
+	delay := If(opts.MaxDelay > 0 && opts.Delay > opts.MaxDelay, opts.MaxDelay, opts.Delay)
+	for attempt := 1; ; attempt++ {
+		val, err := retryAttempt(ctx, opts.AttemptTimeout, fn)
+		if err == nil {
+			return val, nil
+		}
+		if ctx.Err() != nil {
+			return val, &RetryError{Attempts: attempt, Err: ctx.Err(), Cause: If(err == ctx.Err(), nil, err)}
+		}
+		if attempt >= opts.Attempts || (opts.Retryable != nil && !opts.Retryable(err)) {
+			return val, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		wait := delay
+		if opts.Jitter > 0 {
+			wait -= time.Duration(float64(wait) * opts.Jitter * rand.Float64())
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			var r T
+			return r, &RetryError{Attempts: attempt, Err: ctx.Err(), Cause: err}
+		}
+
+		// Clamp before converting, a float too big for a time.Duration overflows into a negative delay
+		next := float64(delay) * opts.Multiplier
+		delay = If(next >= math.MaxInt64, time.Duration(math.MaxInt64), time.Duration(next))
+		if opts.MaxDelay > 0 && delay > opts.MaxDelay {
+			delay = opts.MaxDelay
+		}
+	}
+}
+
+// Makes a single attempt for Retry, limited to timeout if there is one.
+func retryAttempt[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return TimeoutCtxErr(ctx, fn)
+}
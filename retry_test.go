@@ -0,0 +1,129 @@
+package missing_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	val, err := missing.Retry(context.Background(), missing.RetryOptions{Delay: time.Millisecond},
+		func(ctx context.Context) (int, error) {
+			calls++
+			if calls < 3 {
+				return 0, errors.New("not yet")
+			}
+			return 42, nil
+		})
+	if val != 42 || err != nil {
+		t.Errorf("Retry didn't return the successful attempt: %d %v", val, err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	blerg := errors.New("blerg")
+	calls := 0
+	_, err := missing.Retry(context.Background(), missing.RetryOptions{Attempts: 4, Delay: time.Millisecond, Jitter: 0.5},
+		func(ctx context.Context) (int, error) {
+			calls++
+			return 0, blerg
+		})
+	var rerr *missing.RetryError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("Retry didn't return a RetryError: %v", err)
+	}
+	if rerr.Attempts != 4 || calls != 4 {
+		t.Errorf("Expected 4 attempts, got %d (%d calls)", rerr.Attempts, calls)
+	}
+	if !errors.Is(err, blerg) {
+		t.Errorf("RetryError doesn't wrap the last error: %v", err)
+	}
+
+	// Errors that aren't retryable stop straight away
+	calls = 0
+	_, err = missing.Retry(context.Background(), missing.RetryOptions{
+		Attempts:  4,
+		Delay:     time.Millisecond,
+		Retryable: func(err error) bool { return err != blerg },
+	}, func(ctx context.Context) (int, error) {
+		calls++
+		return 0, blerg
+	})
+	if calls != 1 || !errors.Is(err, blerg) {
+		t.Errorf("Retry retried an error that isn't retryable: %d calls, %v", calls, err)
+	}
+}
+
+func TestRetryAttemptTimeout(t *testing.T) {
+	var calls int32
+	val, err := missing.Retry(context.Background(), missing.RetryOptions{
+		Delay:          time.Millisecond,
+		AttemptTimeout: 20 * time.Millisecond,
+	}, func(ctx context.Context) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return 42, nil
+	})
+	if val != 42 || err != nil || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Retry didn't retry a timed out attempt: %d %v (%d calls)", val, err, calls)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	blerg := errors.New("blerg")
+	_, err := missing.Retry(ctx, missing.RetryOptions{Attempts: 100, Delay: 20 * time.Millisecond},
+		func(ctx context.Context) (int, error) {
+			return 0, blerg
+		})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Retry didn't stop when the context expired: %v", err)
+	}
+	if !errors.Is(err, blerg) {
+		t.Errorf("RetryError lost the last attempt's error when the context expired: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Retry kept going long after the context expired")
+	}
+}
+
+// A delay that grows past the largest time.Duration must stay huge, not wrap around to negative and retry at once.
+func TestRetryDelayOverflow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var calls int32
+	_, err := missing.Retry(ctx, missing.RetryOptions{Attempts: 3, Delay: time.Millisecond, Multiplier: 1e30},
+		func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 0, errors.New("fail")
+		})
+	if !errors.Is(err, context.DeadlineExceeded) || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Retry didn't wait after the delay overflowed: %d calls, %v", calls, err)
+	}
+}
+
+func TestRetryMaxDelayFirstWait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	var calls int32
+	_, err := missing.Retry(ctx, missing.RetryOptions{Delay: 5 * time.Second, MaxDelay: 10 * time.Millisecond},
+		func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 0, errors.New("fail")
+		})
+	if ctx.Err() != nil || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("MaxDelay didn't limit the first delay: %d calls, %v", calls, err)
+	}
+}
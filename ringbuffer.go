@@ -0,0 +1,166 @@
+package missing
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// A RingBuffer holds up to a fixed number of values. Once it is full, pushing a new value overwrites the oldest, so it
+// always holds the most recent values, which makes it ideal for sliding windows and "last n" logs. Nothing is ever
+// allocated after it is created.
+//
+// Like a Set it json encodes as an array, from oldest to newest.
+//
+// Create it with NewRingBuffer. The zero value has no capacity, so Push panics, although it can be unmarshalled
+// into (which gives it the capacity of the json array).
+//
+//   recent := missing.NewRingBuffer[string](3)
+//   recent.Push("a", "b", "c", "d")
+//   fmt.Println(recent) // [b c d]
+type RingBuffer[T any] struct {
+	buf  []T
+	head int // Index of the oldest value in buf
+	len  int
+}
+
+// Creates an empty ring buffer that holds up to capacity values. Panics if capacity is less than 1.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("missing: RingBuffer capacity must be at least 1")
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// Returns the number of values in the buffer.
+func (r RingBuffer[T]) Len() int {
+	return r.len
+}
+
+// Returns the most values the buffer can hold.
+func (r RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Returns true if the buffer is full, so the next Push will overwrite the oldest value.
+func (r RingBuffer[T]) Full() bool {
+	return r.len == len(r.buf)
+}
+
+// Adds the values to the buffer, in order. If the buffer is full, each value overwrites the oldest. Panics if the
+// buffer has no capacity (it wasn't created with NewRingBuffer).
+func (r *RingBuffer[T]) Push(vals ...T) {
+	if len(r.buf) == 0 && len(vals) > 0 {
+		panic("missing: RingBuffer has no capacity, create it with NewRingBuffer")
+	}
+	for _, v := range vals {
+		if r.Full() {
+			r.buf[r.head] = v
+			r.head = r.index(1)
+		} else {
+			r.buf[r.index(r.len)] = v
+			r.len++
+		}
+	}
+}
+
+// Removes the oldest value from the buffer and returns it, or returns false if the buffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.len == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = zero // Don't keep a reference to whatever was there
+	r.head = r.index(1)
+	r.len--
+	return v, true
+}
+
+// Returns the oldest value in the buffer, or false if the buffer is empty.
+func (r RingBuffer[T]) Oldest() (T, bool) {
+	if r.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.head], true
+}
+
+// Returns the newest value in the buffer, or false if the buffer is empty.
+func (r RingBuffer[T]) Newest() (T, bool) {
+	if r.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.index(r.len-1)], true
+}
+
+// Returns the value at the specified index (0 is the oldest), or an ErrOutOfRange error if there isn't one.
+func (r RingBuffer[T]) At(index int) (T, error) {
+	if index < 0 || index >= r.len {
+		var zero T
+		return zero, outOfRange(index, r.len)
+	}
+	return r.buf[r.index(index)], nil
+}
+
+// Removes every value from the buffer. The capacity stays the same.
+func (r *RingBuffer[T]) Clear() {
+	var zero T
+	for i := range r.buf {
+		r.buf[i] = zero
+	}
+	r.head = 0
+	r.len = 0
+}
+
+// Returns a Seq of the values in the buffer, from oldest to newest. (See Seq)
+func (r RingBuffer[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.len; i++ {
+			if !yield(r.buf[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Creates a copy of the buffer as a slice, from oldest to newest.
+func (r RingBuffer[T]) ToSlice() []T {
+	list := make([]T, r.len)
+	for i := range list {
+		list[i] = r.buf[r.index(i)]
+	}
+	return list
+}
+
+// A string representation of the buffer (a string formated list, from oldest to newest)
+func (r RingBuffer[T]) String() string {
+	return fmt.Sprint(r.ToSlice())
+}
+
+// A ring buffer marshalls into a json array, from oldest to newest.
+func (r RingBuffer[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.ToSlice())
+}
+
+// A ring buffer unmarshalls from a json array, oldest first. If the array is longer than the buffer's capacity only
+// the newest values are kept. If the buffer has no capacity (it wasn't created with NewRingBuffer), it is given
+// exactly enough for the array.
+func (r *RingBuffer[T]) UnmarshalJSON(b []byte) error {
+	var list []T
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	if len(r.buf) == 0 {
+		*r = *NewRingBuffer[T](If(len(list) > 0, len(list), 1))
+	}
+	r.Clear()
+	r.Push(list...)
+	return nil
+}
+
+// Returns the index into buf of the i'th value.
+func (r RingBuffer[T]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
@@ -0,0 +1,105 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleRingBuffer() {
+	recent := missing.NewRingBuffer[string](3)
+	recent.Push("a", "b", "c", "d")
+	fmt.Println(recent)
+	// Output: [b c d]
+}
+
+func TestRingBuffer(t *testing.T) {
+	r := missing.NewRingBuffer[int](3)
+	if r.Len() != 0 || r.Cap() != 3 || r.Full() {
+		t.Error("New RingBuffer isn't empty")
+	}
+	r.Push(1, 2)
+	if r.String() != "[1 2]" || r.Full() {
+		t.Errorf("Push is wrong: %v", r)
+	}
+	r.Push(3, 4, 5)
+	if r.String() != "[3 4 5]" || !r.Full() || r.Len() != 3 {
+		t.Errorf("Push didn't overwrite the oldest: %v", r)
+	}
+	if v, _ := r.Oldest(); v != 3 {
+		t.Errorf("Oldest is wrong: %d", v)
+	}
+	if v, _ := r.Newest(); v != 5 {
+		t.Errorf("Newest is wrong: %d", v)
+	}
+	if v, err := r.At(1); v != 4 || err != nil {
+		t.Errorf("At(1) is wrong: %d %v", v, err)
+	}
+	if _, err := r.At(3); !errors.Is(err, missing.ErrOutOfRange) {
+		t.Errorf("At past the end didn't return ErrOutOfRange: %v", err)
+	}
+	if v, ok := r.Pop(); v != 3 || !ok || r.String() != "[4 5]" {
+		t.Errorf("Pop is wrong: %d %v", v, r)
+	}
+	r.Push(6, 7)
+	if fmt.Sprint(r.All().Collect()) != "[5 6 7]" {
+		t.Errorf("All is wrong: %v", r.All().Collect())
+	}
+	r.Clear()
+	if _, ok := r.Pop(); ok || r.Cap() != 3 {
+		t.Error("Clear is wrong")
+	}
+}
+
+func TestRingBufferZeroValue(t *testing.T) {
+	var r missing.RingBuffer[int]
+	if _, ok := r.Pop(); ok || r.Len() != 0 || r.Cap() != 0 {
+		t.Error("Zero value RingBuffer isn't empty")
+	}
+	defer func() {
+		if msg, _ := recover().(string); !strings.Contains(msg, "NewRingBuffer") {
+			t.Errorf("Push on a zero value RingBuffer didn't panic clearly: %v", msg)
+		}
+	}()
+	r.Push(1)
+}
+
+func TestRingBufferJson(t *testing.T) {
+	r := missing.NewRingBuffer[int](2)
+	r.Push(1, 2, 3)
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != "[2,3]" {
+		t.Errorf("RingBuffer didn't marshal to an array: %s", bytes)
+	}
+	var s missing.RingBuffer[int]
+	if err := json.Unmarshal([]byte("[1,2,3]"), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.String() != "[1 2 3]" || s.Cap() != 3 {
+		t.Errorf("RingBuffer didn't unmarshal: %v", s.String())
+	}
+	small := missing.NewRingBuffer[int](2)
+	if err := json.Unmarshal([]byte("[1,2,3]"), small); err != nil {
+		t.Fatal(err)
+	}
+	if small.String() != "[2 3]" {
+		t.Errorf("RingBuffer didn't keep the newest values: %v", small)
+	}
+
+	// Held by value in a struct
+	held := struct{ R missing.RingBuffer[int] }{*small}
+	bytes, err = json.Marshal(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"R":[2,3]}` {
+		t.Errorf("RingBuffer held by value didn't marshal: %s", bytes)
+	}
+}
@@ -33,6 +33,17 @@ func (s Set[T]) ToSlice() []T {
 	return keys
 }
 
+// Returns a Seq of the items in the set, in no particular order. (See Seq)
+func (s Set[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
 // Returns true if the set contains the provided value. Does a direct == comparison. Which is to say if your
 // set contains pointers to objects, then it returns true if it is the same pointer. If your set contains
 // the objects themselves, then returns true if the objects are equivilent.
@@ -107,6 +118,138 @@ func (a Set[T]) Intersection(b Set[T]) Set[T] {
 	return intersection
 }
 
+// Returns the symmetric difference of sets a and b, that is to say things that are in either 'a' or 'b', but not
+// both.
+func (a Set[T]) SymmetricDifference(b Set[T]) Set[T] {
+	diff := make(Set[T])
+	for k := range a {
+		if _, found := b[k]; !found {
+			diff[k] = struct{}{}
+		}
+	}
+	for k := range b {
+		if _, found := a[k]; !found {
+			diff[k] = struct{}{}
+		}
+	}
+	return diff
+}
+
+// Returns the union of all the supplied sets, as a new set.
+func UnionAll[T comparable](sets ...Set[T]) Set[T] {
+	union := make(Set[T])
+	for _, s := range sets {
+		union.AddSet(s)
+	}
+	return union
+}
+
+// Returns the intersection of all the supplied sets, as a new set. That is, only things that are in every set. The
+// intersection of no sets is an empty set.
+func IntersectAll[T comparable](sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return Set[T]{}
+	}
+	// Start with the smallest set, as the result can't be any bigger.
+	smallest := 0
+	for i, s := range sets {
+		if len(s) < len(sets[smallest]) {
+			smallest = i
+		}
+	}
+	intersection := sets[smallest].Clone()
+	for _, s := range sets {
+		intersection.IntersectWith(s)
+	}
+	return intersection
+}
+
+// Removes everything from this set that isn't also in b. This is the inplace version of Intersection, and doesn't
+// allocate a new set.
+func (a *Set[T]) IntersectWith(b Set[T]) {
+	for k := range *a {
+		if _, found := b[k]; !found {
+			delete(*a, k)
+		}
+	}
+}
+
+// Removes everything in b from this set. This is the inplace version of Difference, and doesn't allocate a new set.
+func (a *Set[T]) SubtractWith(b Set[T]) {
+	for k := range b {
+		delete(*a, k)
+	}
+}
+
+// Returns true if every item in this set is also in b.
+func (a Set[T]) IsSubset(b Set[T]) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for k := range a {
+		if _, found := b[k]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if every item in b is also in this set.
+func (a Set[T]) IsSuperset(b Set[T]) bool {
+	return b.IsSubset(a)
+}
+
+// Returns true if this set and b have nothing in common.
+func (a Set[T]) IsDisjoint(b Set[T]) bool {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	for k := range a {
+		if _, found := b[k]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if both sets contain exactly the same items.
+func (a Set[T]) Equal(b Set[T]) bool {
+	return len(a) == len(b) && a.IsSubset(b)
+}
+
+// Removes individual value(s) from the set. Values that aren't in the set are ignored.
+func (s *Set[T]) Remove(vals ...T) {
+	for _, v := range vals {
+		delete(*s, v)
+	}
+}
+
+// Removes and returns an arbitrary item from the set, or returns false if the set is empty.
+func (s *Set[T]) Pop() (T, bool) {
+	for k := range *s {
+		delete(*s, k)
+		return k, true
+	}
+	var t T
+	return t, false
+}
+
+// Removes everything from the set.
+func (s *Set[T]) Clear() {
+	for k := range *s {
+		delete(*s, k)
+	}
+}
+
+// Returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	clone := make(Set[T], len(s))
+	for k := range s {
+		clone[k] = struct{}{}
+	}
+	return clone
+}
+
 // A string representation of the set (essentially returns a string formated list)
 func (s Set[T]) String() string {
 	return fmt.Sprint(s.ToSlice())
@@ -216,3 +216,83 @@ func TestToString(t *testing.T) {
 		t.Errorf("Set %+v did not format as a list. Got %s", a, str)
 	}
 }
+
+func TestSymmetricDifference(t *testing.T) {
+	a := missing.NewSet([]int{1, 2, 3, 4})
+	b := missing.NewSet([]int{3, 4, 5, 6})
+	d := a.SymmetricDifference(b)
+	if !d.Equal(missing.NewSet([]int{1, 2, 5, 6})) {
+		t.Errorf("SymmetricDifference is wrong: %v", d)
+	}
+}
+
+func TestSubsets(t *testing.T) {
+	a := missing.NewSet([]int{1, 2, 3})
+	b := missing.NewSet([]int{1, 2, 3, 4})
+	c := missing.NewSet([]int{5, 6})
+	if !a.IsSubset(b) || b.IsSubset(a) || !a.IsSubset(a) {
+		t.Error("IsSubset is wrong")
+	}
+	if !b.IsSuperset(a) || a.IsSuperset(b) {
+		t.Error("IsSuperset is wrong")
+	}
+	if !a.IsDisjoint(c) || a.IsDisjoint(b) {
+		t.Error("IsDisjoint is wrong")
+	}
+	if a.Equal(b) || !a.Equal(missing.NewSet([]int{3, 2, 1})) || !missing.NewSet([]int{}).Equal(missing.Set[int]{}) {
+		t.Error("Equal is wrong")
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	a := missing.NewSet([]int{1, 2, 3})
+	a.Remove(2, 99)
+	if a.Contains(2) || a.Length() != 2 {
+		t.Errorf("Remove is wrong: %v", a)
+	}
+	c := a.Clone()
+	v, ok := a.Pop()
+	if !ok || a.Contains(v) || a.Length() != 1 || !c.Contains(v) {
+		t.Errorf("Pop is wrong: %d from %v", v, a)
+	}
+	a.Clear()
+	if a.Length() != 0 {
+		t.Errorf("Clear is wrong: %v", a)
+	}
+	if _, ok := a.Pop(); ok {
+		t.Error("Pop of an empty set returned something")
+	}
+	if c.Length() != 2 {
+		t.Errorf("Clone was changed with the original: %v", c)
+	}
+}
+
+func TestAllSets(t *testing.T) {
+	a := missing.NewSet([]int{1, 2, 3, 4})
+	b := missing.NewSet([]int{2, 3, 4, 5})
+	c := missing.NewSet([]int{3, 4, 5, 6})
+	if u := missing.UnionAll(a, b, c); !u.Equal(missing.NewSet([]int{1, 2, 3, 4, 5, 6})) {
+		t.Errorf("UnionAll is wrong: %v", u)
+	}
+	if i := missing.IntersectAll(a, b, c); !i.Equal(missing.NewSet([]int{3, 4})) {
+		t.Errorf("IntersectAll is wrong: %v", i)
+	}
+	if i := missing.IntersectAll[int](); i.Length() != 0 {
+		t.Errorf("IntersectAll of nothing isn't empty: %v", i)
+	}
+	if a.Length() != 4 {
+		t.Errorf("IntersectAll changed it's input: %v", a)
+	}
+}
+
+func TestInplace(t *testing.T) {
+	a := missing.NewSet([]int{1, 2, 3, 4})
+	a.IntersectWith(missing.NewSet([]int{2, 3, 4, 5}))
+	if !a.Equal(missing.NewSet([]int{2, 3, 4})) {
+		t.Errorf("IntersectWith is wrong: %v", a)
+	}
+	a.SubtractWith(missing.NewSet([]int{4, 5}))
+	if !a.Equal(missing.NewSet([]int{2, 3})) {
+		t.Errorf("SubtractWith is wrong: %v", a)
+	}
+}
@@ -0,0 +1,415 @@
+package missing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// A SortedSet is a set that keeps it's items in sorted order. As well as the usual set operations it can find the
+// smallest and largest items, the nearest item to a value (Floor and Ceiling), iterate over a range, and find an
+// item's position (Rank and At). Add, Remove, Contains and the lookups are all O(log n).
+//
+// Underneath it is a balanced (AVL) binary tree. Items are ordered by a less function; two items are considered
+// the same if neither is less than the other. Create one with NewSortedSet for ordered types (numbers and strings)
+// or NewSortedSetFunc to supply your own ordering. The zero value is an empty set with no ordering, so adding to it
+// panics.
+//
+//   s := missing.NewSortedSet([]int{50, 10, 40, 20})
+//   s.Add(30)
+//   fmt.Println(s)          // [10 20 30 40 50]
+//   floor, _ := s.Floor(35) // 30
+type SortedSet[T any] struct {
+	root *sortedNode[T]
+	less func(a, b T) bool
+}
+
+type sortedNode[T any] struct {
+	val         T
+	left, right *sortedNode[T]
+	height      int
+	size        int // The number of items in this subtree, for Rank and At
+}
+
+// Creates a new sorted set from the provided slice, ordered from smallest to largest.
+func NewSortedSet[T Ordered](slice []T) *SortedSet[T] {
+	return NewSortedSetFunc(func(a, b T) bool { return a < b }, slice)
+}
+
+// Creates a new sorted set from the provided slice, ordered by the less function (which must return true if a
+// sorts before b).
+//
+//   byAge := missing.NewSortedSetFunc(func(a, b Person) bool { return a.Age < b.Age }, people)
+func NewSortedSetFunc[T any](less func(a, b T) bool, slice []T) *SortedSet[T] {
+	s := &SortedSet[T]{less: less}
+	s.Add(slice...)
+	return s
+}
+
+// Returns the length of the set.
+func (s SortedSet[T]) Length() int {
+	return s.root.count()
+}
+
+// Returns true if the set contains the provided value.
+func (s SortedSet[T]) Contains(v T) bool {
+	n := s.root
+	for n != nil {
+		switch {
+		case s.less(v, n.val):
+			n = n.left
+		case s.less(n.val, v):
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Add individual value(s) to the set. Panics if the set has no less function (it wasn't created with NewSortedSet
+// or NewSortedSetFunc).
+func (s *SortedSet[T]) Add(vals ...T) {
+	if s.less == nil && len(vals) > 0 {
+		panic("missing: SortedSet has no less function, create it with NewSortedSet or NewSortedSetFunc")
+	}
+	for _, v := range vals {
+		s.root = s.insert(s.root, v)
+	}
+}
+
+// Adds the records from the slice to this set inplace. Functionaly the same as s.Add(slice...)
+func (s *SortedSet[T]) AddSlice(slice []T) {
+	s.Add(slice...)
+}
+
+// Adds the values from the supplied set to this set. (inplace operation, c.f. Union)
+func (a *SortedSet[T]) AddSet(b *SortedSet[T]) {
+	b.Foreach(func(v T) {
+		a.Add(v)
+	})
+}
+
+// Removes value(s) from the set. Values that aren't in the set are ignored.
+func (s *SortedSet[T]) Remove(vals ...T) {
+	for _, v := range vals {
+		s.root = s.remove(s.root, v)
+	}
+}
+
+// Returns the smallest item in the set, or false if the set is empty.
+func (s SortedSet[T]) Min() (T, bool) {
+	if s.root == nil {
+		var t T
+		return t, false
+	}
+	n := s.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val, true
+}
+
+// Returns the largest item in the set, or false if the set is empty.
+func (s SortedSet[T]) Max() (T, bool) {
+	if s.root == nil {
+		var t T
+		return t, false
+	}
+	n := s.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val, true
+}
+
+// Returns the largest item in the set that is less than or equal to v, or false if there isn't one.
+func (s SortedSet[T]) Floor(v T) (T, bool) {
+	var found *sortedNode[T]
+	for n := s.root; n != nil; {
+		if s.less(v, n.val) {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+	return found.value()
+}
+
+// Returns the smallest item in the set that is greater than or equal to v, or false if there isn't one.
+func (s SortedSet[T]) Ceiling(v T) (T, bool) {
+	var found *sortedNode[T]
+	for n := s.root; n != nil; {
+		if s.less(n.val, v) {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return found.value()
+}
+
+// Returns the number of items in the set that are less than v. If v is in the set, this is it's index in ToSlice.
+func (s SortedSet[T]) Rank(v T) int {
+	rank := 0
+	for n := s.root; n != nil; {
+		if s.less(n.val, v) {
+			rank += n.left.count() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
+
+// Returns the item at the provided index in sorted order (0 is the smallest), or false if the index is out of range.
+func (s SortedSet[T]) At(index int) (T, bool) {
+	if index < 0 || index >= s.Length() {
+		var t T
+		return t, false
+	}
+	n := s.root
+	for {
+		left := n.left.count()
+		switch {
+		case index < left:
+			n = n.left
+		case index > left:
+			index -= left + 1
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+}
+
+// Calls the provided function for each item from `from` to `to` (inclusive), in order, until the function returns
+// false. Do not modify the set inside the callback.
+//
+//   // Print every event in the first minute
+//   events.Range(0, 60, func(secs int) bool {
+//   	fmt.Println(secs)
+//   	return true
+//   })
+func (s SortedSet[T]) Range(from T, to T, fn func(T) bool) {
+	s.walk(s.root, &from, &to, fn)
+}
+
+// Calls the provided function for each item in the set, in order. Do not modify the set inside the callback.
+func (s SortedSet[T]) Foreach(fn func(T)) {
+	s.walk(s.root, nil, nil, func(v T) bool {
+		fn(v)
+		return true
+	})
+}
+
+// Returns a Seq of the items in the set, in order. (See Seq)
+func (s SortedSet[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		s.walk(s.root, nil, nil, yield)
+	}
+}
+
+// Creates a copy of the set as a sorted slice.
+func (s SortedSet[T]) ToSlice() []T {
+	list := make([]T, 0, s.Length())
+	s.Foreach(func(v T) {
+		list = append(list, v)
+	})
+	return list
+}
+
+// Returns the difference of this set and the provided set (a - b). The result is ordered the same as a.
+func (a SortedSet[T]) Difference(b *SortedSet[T]) *SortedSet[T] {
+	diff := &SortedSet[T]{less: a.less}
+	a.Foreach(func(v T) {
+		if !b.Contains(v) {
+			diff.Add(v)
+		}
+	})
+	return diff
+}
+
+// Returns the union of set a + b. The result is ordered the same as a.
+func (a SortedSet[T]) Union(b *SortedSet[T]) *SortedSet[T] {
+	union := &SortedSet[T]{less: a.less}
+	union.AddSet(&a)
+	union.AddSet(b)
+	return union
+}
+
+// Returns the intersection of sets a and b. The result is ordered the same as a.
+func (a SortedSet[T]) Intersection(b *SortedSet[T]) *SortedSet[T] {
+	intersection := &SortedSet[T]{less: a.less}
+	a.Foreach(func(v T) {
+		if b.Contains(v) {
+			intersection.Add(v)
+		}
+	})
+	return intersection
+}
+
+// A string representation of the set (a string formated list, in order)
+func (s SortedSet[T]) String() string {
+	return fmt.Sprint(s.ToSlice())
+}
+
+// A sorted set marshalls into a json array, in order.
+func (s SortedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// A sorted set unmarshalls from a json array, replacing it's contents. Because the set needs to know how to order
+// it's items, it must already have been created with NewSortedSet or NewSortedSetFunc:
+//
+//   s := missing.NewSortedSet[int](nil)
+//   err := json.Unmarshal(data, s)
+func (s *SortedSet[T]) UnmarshalJSON(b []byte) error {
+	if s.less == nil {
+		return errors.New("missing: SortedSet must be created with NewSortedSet before unmarshalling into it")
+	}
+	var list []T
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	s.root = nil
+	s.Add(list...)
+	return nil
+}
+
+// The AVL tree. Each function returns the new root of the subtree it was given, as rotations may change it.
+
+func (s *SortedSet[T]) insert(n *sortedNode[T], v T) *sortedNode[T] {
+	switch {
+	case n == nil:
+		return &sortedNode[T]{val: v, height: 1, size: 1}
+	case s.less(v, n.val):
+		n.left = s.insert(n.left, v)
+	case s.less(n.val, v):
+		n.right = s.insert(n.right, v)
+	default:
+		return n // Already in the set
+	}
+	return n.rebalance()
+}
+
+func (s *SortedSet[T]) remove(n *sortedNode[T], v T) *sortedNode[T] {
+	switch {
+	case n == nil:
+		return nil
+	case s.less(v, n.val):
+		n.left = s.remove(n.left, v)
+	case s.less(n.val, v):
+		n.right = s.remove(n.right, v)
+	default:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// Replace this node with the smallest node of the right subtree.
+		var smallest *sortedNode[T]
+		right := removeMin(n.right, &smallest)
+		smallest.left = n.left
+		smallest.right = right
+		n = smallest
+	}
+	return n.rebalance()
+}
+
+// Removes the smallest node from the subtree, storing it in smallest.
+func removeMin[T any](n *sortedNode[T], smallest **sortedNode[T]) *sortedNode[T] {
+	if n.left == nil {
+		*smallest = n
+		return n.right
+	}
+	n.left = removeMin(n.left, smallest)
+	return n.rebalance()
+}
+
+// In order traversal of the items between from and to (nil for no limit). Returns false once fn has.
+func (s SortedSet[T]) walk(n *sortedNode[T], from *T, to *T, fn func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveFrom := from == nil || !s.less(n.val, *from)
+	belowTo := to == nil || !s.less(*to, n.val)
+	if aboveFrom && !s.walk(n.left, from, to, fn) {
+		return false
+	}
+	if aboveFrom && belowTo && !fn(n.val) {
+		return false
+	}
+	if belowTo {
+		return s.walk(n.right, from, to, fn)
+	}
+	return true
+}
+
+func (n *sortedNode[T]) value() (T, bool) {
+	if n == nil {
+		var t T
+		return t, false
+	}
+	return n.val, true
+}
+
+func (n *sortedNode[T]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *sortedNode[T]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *sortedNode[T]) update() {
+	n.height = 1 + If(n.left.depth() > n.right.depth(), n.left.depth(), n.right.depth())
+	n.size = 1 + n.left.count() + n.right.count()
+}
+
+func (n *sortedNode[T]) rotateLeft() *sortedNode[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *sortedNode[T]) rotateRight() *sortedNode[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// Restores the AVL balance of the node after an insert or remove below it.
+func (n *sortedNode[T]) rebalance() *sortedNode[T] {
+	n.update()
+	switch balance := n.left.depth() - n.right.depth(); {
+	case balance > 1:
+		if n.left.left.depth() < n.left.right.depth() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.depth() < n.right.left.depth() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
@@ -0,0 +1,201 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func ExampleSortedSet() {
+	s := missing.NewSortedSet([]int{50, 10, 40, 20})
+	s.Add(30)
+	fmt.Println(s)
+	floor, _ := s.Floor(35)
+	ceiling, _ := s.Ceiling(35)
+	fmt.Println(floor, ceiling)
+	// Output:
+	// [10 20 30 40 50]
+	// 30 40
+}
+
+func TestSortedSet(t *testing.T) {
+	s := missing.NewSortedSet([]int{5, 3, 9, 1, 7, 3})
+	if s.Length() != 5 {
+		t.Errorf("SortedSet has the wrong length: %d", s.Length())
+	}
+	if s.String() != "[1 3 5 7 9]" {
+		t.Errorf("SortedSet isn't sorted: %s", s)
+	}
+	if min, _ := s.Min(); min != 1 {
+		t.Errorf("Min is wrong: %d", min)
+	}
+	if max, _ := s.Max(); max != 9 {
+		t.Errorf("Max is wrong: %d", max)
+	}
+	if v, ok := s.Floor(6); v != 5 || !ok {
+		t.Errorf("Floor(6) is wrong: %d", v)
+	}
+	if v, ok := s.Floor(7); v != 7 || !ok {
+		t.Errorf("Floor(7) is wrong: %d", v)
+	}
+	if _, ok := s.Floor(0); ok {
+		t.Error("Floor(0) found something")
+	}
+	if v, ok := s.Ceiling(6); v != 7 || !ok {
+		t.Errorf("Ceiling(6) is wrong: %d", v)
+	}
+	if _, ok := s.Ceiling(10); ok {
+		t.Error("Ceiling(10) found something")
+	}
+	if r := s.Rank(7); r != 3 {
+		t.Errorf("Rank(7) is wrong: %d", r)
+	}
+	if r := s.Rank(6); r != 3 {
+		t.Errorf("Rank(6) is wrong: %d", r)
+	}
+	if v, ok := s.At(1); v != 3 || !ok {
+		t.Errorf("At(1) is wrong: %d", v)
+	}
+	if _, ok := s.At(5); ok {
+		t.Error("At(5) found something")
+	}
+	var inRange []int
+	s.Range(3, 7, func(v int) bool {
+		inRange = append(inRange, v)
+		return true
+	})
+	if fmt.Sprint(inRange) != "[3 5 7]" {
+		t.Errorf("Range(3, 7) is wrong: %v", inRange)
+	}
+	inRange = nil
+	s.Range(2, 100, func(v int) bool {
+		inRange = append(inRange, v)
+		return len(inRange) < 2
+	})
+	if fmt.Sprint(inRange) != "[3 5]" {
+		t.Errorf("Range didn't stop when asked: %v", inRange)
+	}
+	s.Remove(5, 100)
+	if s.Contains(5) || s.String() != "[1 3 7 9]" {
+		t.Errorf("Remove is wrong: %s", s)
+	}
+
+	var empty missing.SortedSet[int]
+	if _, ok := empty.Min(); ok || empty.Length() != 0 {
+		t.Error("Empty SortedSet isn't empty")
+	}
+}
+
+func TestSortedSetFunc(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	s := missing.NewSortedSetFunc(func(a, b person) bool { return a.age < b.age }, []person{
+		{"Zaphod", 200}, {"Arthur", 30}, {"Ford", 180},
+	})
+	youngest, _ := s.Min()
+	if youngest.name != "Arthur" {
+		t.Errorf("Youngest is wrong: %v", youngest)
+	}
+	// The same age is the same item
+	s.Add(person{"Trillian", 30})
+	if s.Length() != 3 {
+		t.Errorf("Equal item was added: %v", s)
+	}
+}
+
+// Check the tree stays correct through lots of random adds and removes.
+func TestSortedSetRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	s := missing.NewSortedSet[int](nil)
+	check := missing.Set[int]{}
+	for i := 0; i < 5000; i++ {
+		v := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			s.Remove(v)
+			delete(check, v)
+		} else {
+			s.Add(v)
+			check.Add(v)
+		}
+	}
+	want := check.ToSlice()
+	sort.Ints(want)
+	if fmt.Sprint(s.ToSlice()) != fmt.Sprint(want) {
+		t.Fatalf("SortedSet doesn't match:\n%v\n%v", s.ToSlice(), want)
+	}
+	for i, v := range want {
+		if r := s.Rank(v); r != i {
+			t.Fatalf("Rank(%d) = %d, expected %d", v, r, i)
+		}
+		if at, _ := s.At(i); at != v {
+			t.Fatalf("At(%d) = %d, expected %d", i, at, v)
+		}
+	}
+}
+
+func TestSortedSetAlgebra(t *testing.T) {
+	a := missing.NewSortedSet([]int{1, 2, 3, 4, 5})
+	b := missing.NewSortedSet([]int{4, 5, 6, 7})
+	if u := a.Union(b).String(); u != "[1 2 3 4 5 6 7]" {
+		t.Errorf("Union is wrong: %s", u)
+	}
+	if i := a.Intersection(b).String(); i != "[4 5]" {
+		t.Errorf("Intersection is wrong: %s", i)
+	}
+	if d := a.Difference(b).String(); d != "[1 2 3]" {
+		t.Errorf("Difference is wrong: %s", d)
+	}
+}
+
+func TestSortedSetJson(t *testing.T) {
+	a := missing.NewSortedSet([]string{"mango", "apple", "zebra"})
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `["apple","mango","zebra"]` {
+		t.Errorf("SortedSet didn't marshal in order: %s", bytes)
+	}
+	b := missing.NewSortedSet[string](nil)
+	if err := json.Unmarshal([]byte(`["c","a","b"]`), b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "[a b c]" {
+		t.Errorf("SortedSet didn't unmarshal: %s", b)
+	}
+	var c missing.SortedSet[string]
+	if err := json.Unmarshal(bytes, &c); err == nil {
+		t.Error("Unmarshalling into a SortedSet without a comparator didn't error")
+	}
+
+	// Held by value in a struct
+	held := struct{ S missing.SortedSet[string] }{*b}
+	bytes, err = json.Marshal(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"S":["a","b","c"]}` {
+		t.Errorf("SortedSet held by value didn't marshal: %s", bytes)
+	}
+}
+
+func TestSortedSetZeroValue(t *testing.T) {
+	var s missing.SortedSet[int]
+	if s.Length() != 0 || s.Contains(1) {
+		t.Error("Zero value SortedSet isn't empty")
+	}
+	s.Remove(1)
+	defer func() {
+		if msg, _ := recover().(string); !strings.Contains(msg, "NewSortedSet") {
+			t.Errorf("Add on a zero value SortedSet didn't panic clearly: %v", msg)
+		}
+	}()
+	s.Add(1)
+}
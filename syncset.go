@@ -0,0 +1,166 @@
+package missing
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// A SyncSet is a Set that is safe to use from multiple goroutines at once, with the same methods as Set. Underneath
+// it is a Set guarded by a sync.RWMutex, so lookups can happen in parallel but changes are one at a time.
+//
+// The zero value is an empty set ready to use, but a SyncSet must not be copied after first use (pass a pointer).
+//
+//   var seen missing.SyncSet[string]
+//   http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//   	if seen.AddIfAbsent(r.RemoteAddr) {
+//   		fmt.Fprintln(w, "Hello stranger")
+//   	}
+//   })
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+// Creates a new SyncSet, using the provided slice.
+func NewSyncSet[T comparable](slice []T) *SyncSet[T] {
+	return &SyncSet[T]{set: NewSet(slice)}
+}
+
+// Returns a copy of the set as a plain Set, which can then be used without any locking.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snap := make(Set[T], len(s.set))
+	for k := range s.set {
+		snap[k] = struct{}{}
+	}
+	return snap
+}
+
+// Creates a copy of the set as a slice.
+func (s *SyncSet[T]) ToSlice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.ToSlice()
+}
+
+// Returns a Seq of the items in the set, in no particular order. The Seq iterates over a Snapshot taken when it is
+// called, so the set isn't locked while you use the items.
+func (s *SyncSet[T]) All() Seq[T] {
+	return func(yield func(T) bool) {
+		s.Snapshot().All()(yield)
+	}
+}
+
+// Returns true if the set contains the provided value.
+func (s *SyncSet[T]) Contains(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(v)
+}
+
+// Returns the length of the set.
+func (s *SyncSet[T]) Length() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.set)
+}
+
+// Add individual value(s) to the set.
+func (s *SyncSet[T]) Add(vals ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	s.set.Add(vals...)
+}
+
+// Adds the value to the set, and returns true, if it isn't already in the set. Otherwise returns false. Checking
+// and adding happen as one step, so if many goroutines try to add the same value only one of them gets true.
+func (s *SyncSet[T]) AddIfAbsent(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init()
+	if s.set.Contains(v) {
+		return false
+	}
+	s.set[v] = struct{}{}
+	return true
+}
+
+// Adds the records from the slice to this set inplace.
+func (s *SyncSet[T]) AddSlice(slice []T) {
+	s.Add(slice...)
+}
+
+// Adds the values from the supplied set to this set. (inplace operation, c.f. Union)
+func (a *SyncSet[T]) AddSet(b *SyncSet[T]) {
+	snap := b.Snapshot()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.init()
+	a.set.AddSet(snap)
+}
+
+// Removes value(s) from the set. Values that aren't in the set are ignored.
+func (s *SyncSet[T]) Remove(vals ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range vals {
+		delete(s.set, v)
+	}
+}
+
+// Returns a new set that is the difference of this set and the provided set. (a - b)
+func (a *SyncSet[T]) Difference(b *SyncSet[T]) *SyncSet[T] {
+	snap := b.Snapshot()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return &SyncSet[T]{set: a.set.Difference(snap)}
+}
+
+// Returns a new set that is the union of sets a + b.
+func (a *SyncSet[T]) Union(b *SyncSet[T]) *SyncSet[T] {
+	snap := b.Snapshot()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return &SyncSet[T]{set: a.set.Union(snap)}
+}
+
+// Returns a new set that is the intersection of sets a and b.
+func (a *SyncSet[T]) Intersection(b *SyncSet[T]) *SyncSet[T] {
+	snap := b.Snapshot()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return &SyncSet[T]{set: a.set.Intersection(snap)}
+}
+
+// A string representation of the set (essentially returns a string formated list)
+func (s *SyncSet[T]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.String()
+}
+
+// A set marshalls into a json array.
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// A set unmarshalls from a json array.
+func (s *SyncSet[T]) UnmarshalJSON(b []byte) error {
+	var set Set[T]
+	if err := json.Unmarshal(b, &set); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = set
+	return nil
+}
+
+// Creates the underlying set on first use, so the zero value works. Must be called with the lock held.
+func (s *SyncSet[T]) init() {
+	if s.set == nil {
+		s.set = Set[T]{}
+	}
+}
@@ -0,0 +1,90 @@
+package missing_test
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zafnz/go-missing"
+)
+
+func TestSyncSet(t *testing.T) {
+	var s missing.SyncSet[int]
+	if s.Length() != 0 || s.Contains(1) {
+		t.Error("Zero value SyncSet isn't empty")
+	}
+	s.Add(1, 2, 3)
+	s.AddSlice([]int{4, 5})
+	if s.Length() != 5 || !s.Contains(5) {
+		t.Errorf("SyncSet didn't add values: %v", s.String())
+	}
+	s.Remove(5, 99)
+	if s.Contains(5) || s.Length() != 4 {
+		t.Errorf("SyncSet didn't remove value: %v", s.String())
+	}
+	b := missing.NewSyncSet([]int{3, 4, 10})
+	if u := s.Union(b); u.Length() != 5 || !u.Contains(10) {
+		t.Errorf("SyncSet union is wrong: %v", u)
+	}
+	if i := s.Intersection(b); i.Length() != 2 || !i.Contains(3) || !i.Contains(4) {
+		t.Errorf("SyncSet intersection is wrong: %v", i)
+	}
+	if d := s.Difference(b); d.Length() != 2 || !d.Contains(1) || !d.Contains(2) {
+		t.Errorf("SyncSet difference is wrong: %v", d)
+	}
+	s.AddSet(b)
+	if s.Length() != 5 || !s.Contains(10) {
+		t.Errorf("SyncSet AddSet is wrong: %v", s.String())
+	}
+
+	snap := s.Snapshot()
+	s.Add(11)
+	if snap.Contains(11) {
+		t.Error("Snapshot changed when the SyncSet did")
+	}
+}
+
+func TestSyncSetJson(t *testing.T) {
+	a := missing.NewSyncSet([]int{777, 12345})
+	bytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b missing.SyncSet[int]
+	if err := json.Unmarshal(bytes, &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Length() != 2 || !b.Contains(777) || !b.Contains(12345) {
+		t.Errorf("SyncSet didn't survive json: %s -> %v", bytes, b.String())
+	}
+}
+
+// Run with -race to check the locking.
+func TestSyncSetConcurrent(t *testing.T) {
+	s := missing.NewSyncSet[int](nil)
+	var wg sync.WaitGroup
+	var added int32
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if s.AddIfAbsent(i) {
+					atomic.AddInt32(&added, 1)
+				}
+				s.Contains(i)
+				s.Add(i + 1000*g)
+				if i%100 == 0 {
+					s.Union(s)
+					s.Snapshot()
+					s.ToSlice()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if added != 1000 {
+		t.Errorf("AddIfAbsent returned true %d times for 1000 values", added)
+	}
+}